@@ -15,7 +15,7 @@ type Device struct {
 	state      *models.Device
 	stateMutex sync.Mutex
 	specs      model.DeviceSpecs
-	modulesReg ModulesRegistry
+	modulesReg *ModulesRegistry
 
 	cacheLayer
 
@@ -23,6 +23,8 @@ type Device struct {
 	staticSensors sensor.SensorsRegister
 
 	active       bool
+	paused       int32
+	pauseMutex   sync.Mutex
 	cancelDevice context.CancelFunc
 }
 
@@ -38,7 +40,7 @@ func New(modules ...Module) *Device {
 		cancelDevice:  cancel,
 	}
 
-	dev.modulesReg = modules
+	dev.modulesReg = newModulesRegistry(modules...)
 
 	return dev
 }
@@ -67,11 +69,24 @@ func (d *Device) IsLoggedToNetwork() bool {
 }
 
 // Start performs startup of the Device and setting up all registered modules.
+// Modules are started in order of their dependencies once readiness conditions required by them are met.
 func (d *Device) Start() {
+	d.restorePause()
+	d.watchConfigPatch()
 	d.modulesReg.Setup(d)
 	d.modulesReg.Start(d.ctx)
 }
 
+// BlockedModules returns modules awaiting for unmet dependencies or readiness conditions along with descriptions of those.
+func (d *Device) BlockedModules() map[string][]string {
+	return d.modulesReg.Blocked()
+}
+
+// ModulesHealth returns health state of the registered modules, including their status and restarts count.
+func (d *Device) ModulesHealth() []model.ModuleHealth {
+	return d.modulesReg.supervisor.Health()
+}
+
 // Close stops all working device.Module and frees allocated resources.
 func (d *Device) Close() error {
 	d.active = false
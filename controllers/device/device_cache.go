@@ -16,10 +16,11 @@ type (
 		requests *requirementsCache
 	}
 
-	// assetsCache defines structure for storing models.Asset records data taken from blockchain.
+	// assetsCache defines structure for storing models.Asset records data taken from blockchain,
+	// where asset names are stored by their IDs.
 	assetsCache struct {
 		mutex sync.Mutex
-		data  map[string]bool
+		data  map[string]string
 	}
 
 	// requirementsCache defines structure for storing models.Requirements records data taken from blockchain.
@@ -34,7 +35,7 @@ func newCacheLayer() cacheLayer {
 	return cacheLayer{
 		assets: &assetsCache{
 			mutex: sync.Mutex{},
-			data:  make(map[string]bool),
+			data:  make(map[string]string),
 		},
 		requests: &requirementsCache{
 			mutex: sync.Mutex{},
@@ -61,6 +62,19 @@ func (c *cacheLayer) GetCachedAssets() []string {
 	return ids
 }
 
+// GetCachedAssetName returns name of the models.Asset record stored in cache by given `id`,
+// or the `id` itself when asset has no name or isn't cached.
+func (c *cacheLayer) GetCachedAssetName(id string) string {
+	c.assets.mutex.Lock()
+	defer c.assets.mutex.Unlock()
+
+	if name := c.assets.data[id]; len(name) != 0 {
+		return name
+	}
+
+	return id
+}
+
 // ExistsAssetInCache determines whether the models.Asset record stored in cache by given `id`.
 func (c *cacheLayer) ExistsAssetInCache(id string) bool {
 	c.assets.mutex.Lock()
@@ -76,7 +90,7 @@ func (c *cacheLayer) PutAssetsToCache(assets ...*models.Asset) {
 	defer c.assets.mutex.Unlock()
 
 	for i := range assets {
-		c.assets.data[assets[i].ID] = true
+		c.assets.data[assets[i].ID] = assets[i].Name
 	}
 }
 
@@ -93,7 +107,7 @@ func (c *cacheLayer) FlushAssetsCache() {
 	c.assets.mutex.Lock()
 	defer c.assets.mutex.Unlock()
 
-	c.assets.data = make(map[string]bool)
+	c.assets.data = make(map[string]string)
 }
 
 // GetCachedRequirements returns data of cached models.Requirements records as model.SensorsReadingRequest.
@@ -154,6 +168,7 @@ func (c *cacheLayer) PutRequirementsToCache(reqs ...*models.Requirements) (reque
 		request := &model.SensorsReadingRequest{
 			AssetID: req.AssetID,
 			Metrics: req.Metrics.Metrics(),
+			Limits:  req.Metrics,
 			Period:  time.Second * time.Duration(req.Period),
 		}
 
@@ -0,0 +1,33 @@
+package device
+
+import (
+	"github.com/timoth-y/chainmetric-iot/network/blockchain"
+)
+
+// Condition defines readiness condition of the Device, which Module may require to be met before it is started.
+type Condition string
+
+const (
+	// LoggedOnNetwork is met once the Device is logged on blockchain network.
+	LoggedOnNetwork Condition = "logged_on_network"
+	// BlockchainConnected is met once the blockchain client is connected to network.
+	BlockchainConnected Condition = "blockchain_connected"
+)
+
+// ConditionMet determines whether the readiness `condition` is met by the Device at the moment.
+func (d *Device) ConditionMet(condition Condition) bool {
+	switch condition {
+	case LoggedOnNetwork:
+		return d.IsLoggedToNetwork()
+	case BlockchainConnected:
+		return blockchain.IsConnected()
+	default:
+		return false
+	}
+}
+
+// signalReadiness notifies registered modules about changes in Device readiness conditions,
+// so that ones awaiting for them could be started.
+func (d *Device) signalReadiness() {
+	d.modulesReg.startPending()
+}
@@ -0,0 +1,44 @@
+package device
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/timoth-y/go-eventdriver"
+
+	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// watchConfigPatch awaits for the Device to log on network after the remote config patch was applied,
+// otherwise the last-known-good config is restored and reboot is requested once `remote.config_confirm_timeout` expires.
+func (d *Device) watchConfigPatch() {
+	if !shared.ConfigPatchPending() {
+		return
+	}
+
+	shared.Logger.Warningf("Config patch of version %d awaits confirmation", shared.ConfigVersion())
+
+	go func() {
+		select {
+		case <-time.After(viper.GetDuration("remote.config_confirm_timeout")):
+		case <-d.ctx.Done():
+			return
+		}
+
+		if !shared.ConfigPatchPending() {
+			return
+		}
+
+		if err := shared.RollbackConfigPatch(); err != nil {
+			shared.Logger.Error(errors.Wrap(err, "failed to rollback config patch"))
+			return
+		}
+
+		eventdriver.EmitEvent(d.ctx, events.DeviceShutdownRequested, events.DeviceShutdownRequestedPayload{
+			Reason:  "Config rolled back",
+			Command: viper.GetString("remote.reboot_command"),
+		})
+	}()
+}
@@ -0,0 +1,112 @@
+package device
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/go-eventdriver"
+
+	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// IsPaused determines whether the Device operation is paused, e.g. by remote command.
+func (d *Device) IsPaused() bool {
+	return atomic.LoadInt32(&d.paused) == 1
+}
+
+// Pause suspends Device operation: sets its state on blockchain ledger to models.DevicePaused
+// and notifies modules with events.DevicePaused, so that they would stop reading sensors.
+// Paused state is persisted, so that the Device would stay paused after reboot.
+func (d *Device) Pause(ctx context.Context) error {
+	// Concurrent pause and resume commands are serialized, so that state won't be changed twice:
+	d.pauseMutex.Lock()
+	defer d.pauseMutex.Unlock()
+
+	if d.IsPaused() {
+		return errors.New("device is already paused")
+	}
+
+	if err := persistPause(true); err != nil {
+		return errors.Wrap(err, "failed to persist paused state")
+	}
+
+	if err := d.SetState(models.DevicePaused); err != nil {
+		shared.Execute(func() error {
+			return persistPause(false)
+		}, "failed to revert persisted paused state")
+
+		return err
+	}
+
+	atomic.StoreInt32(&d.paused, 1)
+	eventdriver.EmitEvent(ctx, events.DevicePaused, nil)
+
+	shared.Logger.Info("Device operation has been paused")
+
+	return nil
+}
+
+// Resume restores Device operation suspended with Pause: sets its state on blockchain ledger to models.DeviceOnline
+// and notifies modules with events.DeviceResumed, so that they would continue reading sensors.
+func (d *Device) Resume(ctx context.Context) error {
+	d.pauseMutex.Lock()
+	defer d.pauseMutex.Unlock()
+
+	if !d.IsPaused() {
+		return errors.New("device isn't paused")
+	}
+
+	if err := d.SetState(models.DeviceOnline); err != nil {
+		return err
+	}
+
+	if err := persistPause(false); err != nil {
+		shared.Logger.Error(errors.Wrap(err, "failed to clear persisted paused state"))
+	}
+
+	atomic.StoreInt32(&d.paused, 0)
+	eventdriver.EmitEvent(ctx, events.DeviceResumed, nil)
+
+	shared.Logger.Info("Device operation has been resumed")
+
+	return nil
+}
+
+// restorePause restores paused state of the Device persisted before reboot.
+func (d *Device) restorePause() {
+	if _, err := os.Stat(viper.GetString("device.pause_file_path")); err != nil {
+		if !os.IsNotExist(err) {
+			shared.Logger.Error(errors.Wrap(err, "failed to read persisted paused state"))
+		}
+
+		return
+	}
+
+	atomic.StoreInt32(&d.paused, 1)
+	shared.Logger.Warning("Device operation is paused since before reboot")
+}
+
+// persistPause persists paused state of the Device by creating or removing pause file.
+func persistPause(paused bool) error {
+	var path = viper.GetString("device.pause_file_path")
+
+	if !paused {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -15,9 +15,15 @@ import (
 // it just updates device locally saved properties.
 func (d *Device) UpdateDeviceModel(model *models.Device) {
 	d.stateMutex.Lock()
-	defer d.stateMutex.Unlock()
-
 	d.state = model
+	d.stateMutex.Unlock()
+
+	// Being logged on network after restart proves that remotely changed config is operational:
+	if model != nil {
+		shared.ConfirmConfigPatch()
+	}
+
+	d.signalReadiness()
 }
 
 // State returns Device current state.
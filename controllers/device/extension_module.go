@@ -8,12 +8,17 @@ import (
 type Module interface {
 	// MID returns Module ID.
 	MID() string
+	// Dependencies returns IDs of the modules, which must be started before this Module.
+	Dependencies() []string
+	// Conditions returns readiness Condition's of the Device, which must be met before this Module is started.
+	Conditions() []Condition
 	// Setup registers logical Module onto the Device instance.
 	Setup(device *Device) error
 	// IsReady determines whether the logical Module's Setup is complete and it is ready to Start.
 	IsReady() bool
-	// Start starts Module operational routine.
-	Start(ctx context.Context)
+	// Start runs Module operational routine, blocking until it ends or `ctx` is done.
+	// Returned error or panic is considered as failure, upon which Module can be restarted by Supervisor.
+	Start(ctx context.Context) error
 	// Close stops Module gracefully and clears allocated resources.
 	Close() error
 }
@@ -21,53 +21,49 @@ type CacheManager struct {
 // WithCacheManager can be used to setup CacheManager logical device.Module onto the device.Device.
 func WithCacheManager() device.Module {
 	return &CacheManager{
-		moduleBase: withModuleBase("CACHE_MANAGER"),
+		moduleBase: withModuleBase("CACHE_MANAGER", dependsOn("ENGINE_OPERATOR"), requires(device.LoggedOnNetwork)),
 	}
 }
 
-func (m *CacheManager) Start(ctx context.Context) {
-	go m.Do(func() {
-		if !m.trySyncWithDeviceLifecycle(ctx, m.Start) {
-			return
+func (m *CacheManager) Start(ctx context.Context) error {
+	// Handle changes that require full cache reload:
+	m.subscribe(ctx, events.DeviceLocationChanged, func(_ context.Context, _ interface{}) error {
+		// Canceling requests before re-caching:
+		for _, request := range m.GetCachedRequirementsFor() {
+			request.Cancel()
 		}
 
-		// Handle changes that require full cache reload:
-		eventdriver.SubscribeHandler(events.DeviceLocationChanged, func(_ context.Context, _ interface{}) error {
-			// Canceling requests before re-caching:
-			for _, request := range m.GetCachedRequirementsFor() {
+		m.cacheBlockchainData(ctx)
+		return nil
+	})
+
+	// Handle changes in assigned assets, which require changes in requirements:
+	m.subscribe(ctx, events.AssetsChanged, func(ctx context.Context, v interface{}) error {
+		if payload, ok := v.(events.AssetsChangedPayload); ok {
+			// Canceling requests for removed assets and removing them from cache:
+			for _, request := range m.GetCachedRequirementsFor(payload.Removed...) {
 				request.Cancel()
+				m.RemoveRequirementsFromCache(request.ID)
 			}
 
-			m.cacheBlockchainData(ctx)
-			return nil
-		})
-
-		// Handle changes in assigned assets, which require changes in requirements:
-		eventdriver.SubscribeHandler(events.AssetsChanged, func(ctx context.Context, v interface{}) error {
-			if payload, ok := v.(events.AssetsChangedPayload); ok {
-				// Canceling requests for removed assets and removing them from cache:
-				for _, request := range m.GetCachedRequirementsFor(payload.Removed...) {
-					request.Cancel()
-					m.RemoveRequirementsFromCache(request.ID)
-				}
-
-				res, err := blockchain.Contracts.Requirements.ReceiveFor(payload.Assigned...)
-				if err != nil {
-					return errors.Wrap(err, "failed to receive requirements for newly assigned assets")
-				}
-
-				eventdriver.EmitEvent(ctx, events.RequirementsChanged, events.RequirementsChangedPayload{
-					Requests: m.PutRequirementsToCache(res...),
-				})
-
-				return nil
+			res, err := blockchain.Contracts.Requirements.ReceiveFor(payload.Assigned...)
+			if err != nil {
+				return errors.Wrap(err, "failed to receive requirements for newly assigned assets")
 			}
 
-			return eventdriver.ErrIncorrectPayload
-		})
+			eventdriver.EmitEvent(ctx, events.RequirementsChanged, events.RequirementsChangedPayload{
+				Requests: m.PutRequirementsToCache(res...),
+			})
 
-		m.cacheBlockchainData(ctx)
+			return nil
+		}
+
+		return eventdriver.ErrIncorrectPayload
 	})
+
+	m.cacheBlockchainData(ctx)
+
+	return m.untilDone(ctx)
 }
 
 func (m *CacheManager) cacheBlockchainData(ctx context.Context) {
@@ -0,0 +1,75 @@
+package modules
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/go-eventdriver"
+
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// runHandler defines local event handler of the module's run bound to `ctx`.
+type runHandler struct {
+	ctx     context.Context
+	handler eventdriver.EventHandlerFunc
+}
+
+var (
+	runHandlers     = make(map[string][]runHandler)
+	runHandlersLock = &sync.RWMutex{}
+)
+
+// subscribeRun subscribes `handler` to the local `event` for the duration of module's run bound to `ctx`.
+//
+// Each event is subscribed on eventdriver only once and dispatched to the handlers of the current runs from there,
+// since eventdriver handlers can't be unsubscribed and aren't safe to be added while events are being delivered.
+// Handlers of the ended runs are dropped, so that those won't pile up with modules being restarted.
+func subscribeRun(ctx context.Context, event string, handler eventdriver.EventHandlerFunc) {
+	runHandlersLock.Lock()
+	defer runHandlersLock.Unlock()
+
+	handlers, subscribed := runHandlers[event]
+	if !subscribed {
+		eventdriver.SubscribeHandler(event, func(ectx context.Context, v interface{}) error {
+			dispatchRun(ectx, event, v)
+			return nil
+		})
+	}
+
+	var live = make([]runHandler, 0, len(handlers)+1)
+
+	for _, h := range handlers {
+		if h.ctx.Err() == nil {
+			live = append(live, h)
+		}
+	}
+
+	runHandlers[event] = append(live, runHandler{
+		ctx:     ctx,
+		handler: handler,
+	})
+}
+
+// dispatchRun passes `event` payload `v` to the handlers of the runs, which haven't ended yet.
+func dispatchRun(ctx context.Context, event string, v interface{}) {
+	runHandlersLock.RLock()
+	var handlers = runHandlers[event]
+	runHandlersLock.RUnlock()
+
+	for _, h := range handlers {
+		if h.ctx.Err() != nil {
+			continue
+		}
+
+		if err := h.handler(ctx, v); err != nil {
+			if err == eventdriver.ErrIncorrectPayload {
+				shared.Logger.Error(errors.Wrap(err, event))
+				continue
+			}
+
+			shared.Logger.Error(errors.Wrapf(err, "failed to handle event %s", event))
+		}
+	}
+}
@@ -2,90 +2,168 @@ package modules
 
 import (
 	"context"
+	"sync"
 	"time"
 
-	"github.com/pkg/errors"
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-core/utils"
 	"github.com/timoth-y/chainmetric-iot/controllers/device"
 	"github.com/timoth-y/chainmetric-iot/controllers/engine"
 	"github.com/timoth-y/chainmetric-iot/model"
 	"github.com/timoth-y/chainmetric-iot/model/events"
-	"github.com/timoth-y/chainmetric-iot/network/blockchain"
 	"github.com/timoth-y/chainmetric-iot/shared"
 	"github.com/timoth-y/go-eventdriver"
 )
 
 // EngineOperator implements device.Module for engine.SensorsReader operating.
+//
+// Event handlers are run concurrently, while engine is replaced on pause and restart,
+// so the engine along with its settings is accessed only under the mutex.
 type EngineOperator struct {
 	moduleBase
+	mutex sync.Mutex
 	engine *engine.SensorsReader
+	samplingFactor float64
+	standbyTimeout time.Duration
 }
 
 // WithEngineOperator can be used to setup EngineOperator logical device.Module onto the device.Device.
 func WithEngineOperator() device.Module {
 	return &EngineOperator{
-		moduleBase: withModuleBase("ENGINE_OPERATOR"),
+		moduleBase: withModuleBase("ENGINE_OPERATOR", requires(device.LoggedOnNetwork)),
 		engine: engine.NewSensorsReader(),
+		samplingFactor: 1,
 	}
 }
 
-func (m *EngineOperator) Start(ctx context.Context) {
-	go m.Do(func() {
-		if !m.trySyncWithDeviceLifecycle(ctx, m.Start) {
-			return
+func (m *EngineOperator) Start(ctx context.Context) error {
+	m.mutex.Lock()
+	if m.engine.Active() {
+		m.resetEngine()
+	} // Engine routine is bound to the context of the previous run, so it must be replaced on restart.
+	m.mutex.Unlock()
+
+	// Listen and act on newly submitted or changed requirements:
+	m.subscribe(ctx, events.RequirementsChanged, func(_ context.Context, v interface{}) error {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		if !m.engine.Active() {
+			return nil
+		}  // No need to act on requests before engine isn't started
+
+		if payload, ok := v.(events.RequirementsChangedPayload); ok {
+			for i := range payload.Requests {
+				m.actOnRequest(ctx, payload.Requests[i])
+			}
+
+			return nil
 		}
 
-		// Listen and act on newly submitted or changed requirements:
-		eventdriver.SubscribeHandler(events.RequirementsChanged, func(_ context.Context, v interface{}) error {
-			if !m.engine.Active() {
-				return nil
-			}  // No need to act on requests before engine isn't started
+		return eventdriver.ErrIncorrectPayload
+	})
 
-			if payload, ok := v.(events.RequirementsChangedPayload); ok {
-				for i := range payload.Requests {
-					m.actOnRequest(ctx, payload.Requests[i])
-				}
+	// Listen and changes in device's sensors register:
+	m.subscribe(ctx, events.SensorsRegisterChanged, func(_ context.Context, v interface{}) error {
+		if payload, ok := v.(events.SensorsRegisterChangedPayload); ok {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
 
-				return nil
+			m.engine.RegisterSensors(payload.Added...)
+			m.engine.UnregisterSensors(payload.Removed...)
+
+			// If engine wasn't started yet it is because there weren't any available sensors before.
+			// If there is ones now, engine could start processing requests.
+			m.startEngine(ctx)
+
+			return nil
+		}
+
+		return eventdriver.ErrIncorrectPayload
+	})
+
+	// Listen and act on power profile changes to save power while running on battery:
+	m.subscribe(ctx, events.PowerProfileChanged, func(_ context.Context, v interface{}) error {
+		if payload, ok := v.(events.PowerProfileChangedPayload); ok {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+
+			m.standbyTimeout = payload.Settings.SensorStandbyTimeout
+			m.engine.SetStandbyTimeout(m.standbyTimeout)
+
+			if payload.Settings.SamplingFactor != m.samplingFactor {
+				m.samplingFactor = payload.Settings.SamplingFactor
+				m.restartCachedRequests(ctx)
 			}
 
-			return eventdriver.ErrIncorrectPayload
-		})
+			return nil
+		}
+
+		return eventdriver.ErrIncorrectPayload
+	})
+
+	// Listen and changes in parameters cache:
+	m.subscribe(ctx, events.CacheChanged, func(_ context.Context, _ interface{}) error {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
 
-		// Listen and changes in device's sensors register:
-		eventdriver.SubscribeHandler(events.SensorsRegisterChanged, func(_ context.Context, v interface{}) error {
-			if payload, ok := v.(events.SensorsRegisterChangedPayload); ok {
-				m.engine.RegisterSensors(payload.Added...)
-				m.engine.UnregisterSensors(payload.Removed...)
+		m.actOnCachedRequests(ctx)
+		return nil
+	})
 
-				// If engine wasn't started yet it is because there weren't any available sensors before.
-				// If there is ones now, engine could start processing requests.
-				if !m.engine.Active() && m.RegisteredSensors().NotEmpty() {
-					m.engine.Run(ctx)
-					m.actOnCachedRequests(ctx)
-				}
+	// Listen and take readings requested right away, e.g. by remote command:
+	m.subscribe(ctx, events.ReadingsRequested, func(_ context.Context, v interface{}) error {
+		if payload, ok := v.(events.ReadingsRequestedPayload); ok {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
 
+			if !m.engine.Active() {
+				close(payload.Results)
 				return nil
-			}
+			} // Readings can't be taken while engine isn't running, e.g. when device is paused.
 
-			return eventdriver.ErrIncorrectPayload
-		})
+			m.engine.SendRequest(func(readings engine.ReadingResults) {
+				m.postReadings(ctx, payload.AssetID, readings)
+				eventdriver.EmitEvent(ctx, events.RequestHandled, events.RequestHandledPayload{
+					AssetID:  payload.AssetID,
+					Readings: readings,
+				})
 
-		// Listen and changes in parameters cache:
-		eventdriver.SubscribeHandler(events.CacheChanged, func(_ context.Context, _ interface{}) error {
-			m.actOnCachedRequests(ctx)
-			return nil
-		})
+				payload.Results <- readings
+			}, payload.Metrics...)
 
-		if m.waitUntilSensorsDetected() {
-			m.engine.RegisterSensors(m.RegisteredSensors().ToList()...)
-			m.engine.Run(ctx)
+			return nil
 		}
+
+		return eventdriver.ErrIncorrectPayload
+	})
+
+	// Stop reading sensors while device is paused and continue once it's resumed:
+	m.subscribe(ctx, events.DevicePaused, func(_ context.Context, _ interface{}) error {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.stopEngine()
+		return nil
 	})
-}
 
+	m.subscribe(ctx, events.DeviceResumed, func(_ context.Context, _ interface{}) error {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
 
+		m.startEngine(ctx)
+		return nil
+	})
+
+	// Sensors detected before the module started won't be reported via events.SensorsRegisterChanged:
+	m.mutex.Lock()
+	m.startEngine(ctx)
+	m.mutex.Unlock()
+
+	return m.untilDone(ctx)
+}
+
+// actOnRequest sends or subscribes readings `request` on the engine, mutex must be held by the caller.
 func (m *EngineOperator) actOnRequest(ctx context.Context, request *model.SensorsReadingRequest) {
 	if request.IsProcessed() {
 		return
@@ -93,8 +171,11 @@ func (m *EngineOperator) actOnRequest(ctx context.Context, request *model.Sensor
 
 	var (
 		handler = func(readings engine.ReadingResults) {
-			m.postReadings(request.AssetID, readings)
-			eventdriver.EmitEvent(ctx, events.RequestHandled, nil)
+			m.postReadings(ctx, request.AssetID, readings)
+			eventdriver.EmitEvent(ctx, events.RequestHandled, events.RequestHandledPayload{
+				AssetID:  request.AssetID,
+				Readings: readings,
+			})
 		}
 	)
 
@@ -105,19 +186,90 @@ func (m *EngineOperator) actOnRequest(ctx context.Context, request *model.Sensor
 		return
 	}
 
-	// Otherwise subscribe receiver with given period of readings:
-	request.SetCancel(m.engine.SubscribeReceiver(ctx, handler, request.Period, request.Metrics...))
+	// Otherwise subscribe receiver with given period of readings, stretched by power profile:
+	var period = time.Duration(float64(request.Period) * m.samplingFactor)
+
+	request.SetCancel(m.engine.SubscribeReceiver(ctx, handler, period, request.Metrics...))
 }
 
+// actOnCachedRequests acts on the cached requests once engine is running, mutex must be held by the caller.
 func (m *EngineOperator) actOnCachedRequests(ctx context.Context) {
+	if !m.engine.Active() {
+		return
+	} // Requests will be acted on once engine is started.
+
 	for _, request := range m.GetCachedRequirements() {
 		m.actOnRequest(ctx, request)
 	}
 }
 
-func (m *EngineOperator) postReadings(assetID string, readings engine.ReadingResults) {
+// restartCachedRequests cancels receivers of the cached requests and subscribes them again,
+// so that current sampling factor is applied to their periods. Mutex must be held by the caller.
+func (m *EngineOperator) restartCachedRequests(ctx context.Context) {
+	if !m.engine.Active() {
+		return
+	}
+
+	for _, request := range m.GetCachedRequirements() {
+		if request.IsProcessed() {
+			request.Cancel()
+			request.SetCancel(nil)
+		}
+
+		m.actOnRequest(ctx, request)
+	}
+}
+
+// startEngine runs engine on device's sensors and acts on cached requests,
+// unless it is already running, there are no sensors available yet, or device is paused. Mutex must be held by the caller.
+func (m *EngineOperator) startEngine(ctx context.Context) {
+	if m.engine.Active() || m.IsPaused() || !m.RegisteredSensors().NotEmpty() {
+		return
+	}
+
+	m.engine.RegisterSensors(m.RegisteredSensors().ToList()...)
+	m.engine.Run(ctx)
+	m.actOnCachedRequests(ctx)
+}
+
+// stopEngine cancels receivers of the cached requests and stops engine, parking its sensors in standby.
+// Engine is replaced with a new instance, so that it could be started again with startEngine.
+// Mutex must be held by the caller.
+func (m *EngineOperator) stopEngine() {
+	for _, request := range m.GetCachedRequirements() {
+		request.Cancel()
+	}
+
+	m.resetEngine()
+}
+
+// resetEngine replaces engine with a new instance, so that cached requests would be handled by it once it runs.
+// Mutex must be held by the caller.
+func (m *EngineOperator) resetEngine() {
+	m.engine.Close()
+	m.engine = engine.NewSensorsReader()
+	m.engine.SetStandbyTimeout(m.standbyTimeout)
+
+	for _, request := range m.GetCachedRequirements() {
+		request.SetCancel(nil)
+	}
+}
+
+// Close stops the engine and waits for readings it is still taking,
+// so that those are posted or persisted in the failover cache before device is shut down.
+func (m *EngineOperator) Close() error {
+	m.mutex.Lock()
+	var engine = m.engine
+	engine.Close()
+	m.mutex.Unlock()
+
+	engine.Wait()
+
+	return m.moduleBase.Close()
+}
+
+func (m *EngineOperator) postReadings(ctx context.Context, assetID string, readings engine.ReadingResults) {
 	var (
-		ctx = context.Background()
 		record = models.MetricReadings{
 			AssetID:   assetID,
 			DeviceID:  m.ID(),
@@ -131,18 +283,7 @@ func (m *EngineOperator) postReadings(assetID string, readings engine.ReadingRes
 		return
 	}
 
-	if err := blockchain.Contracts.Readings.Post(record); err != nil {
-		if detectNetworkAbsence(err) {
-			eventdriver.EmitEvent(ctx, events.MetricReadingsPostFailed, events.MetricReadingsPostFailedPayload{
-				MetricReadings: record,
-				Error: err,
-			})
-		} else {
-			shared.Logger.Error(errors.Wrapf(err, "failed to post readings with id %s%s", utils.Hash(record.AssetID),
-				utils.Hash(string(record.Encode()))))
-		}
-		return
+	if m.postMetricReadings(ctx, record) {
+		shared.Logger.Debugf("Readings for asset %s was posted with => %s", assetID, utils.Prettify(readings))
 	}
-
-	shared.Logger.Debugf("Readings for asset %s was posted with => %s", assetID, utils.Prettify(readings))
 }
@@ -23,23 +23,39 @@ type EventsObserver struct {
 // WithEventsObserver can be used to setup EventsObserver logical device.Module onto the device.Device.
 func WithEventsObserver() device.Module {
 	return &EventsObserver{
-		moduleBase: withModuleBase("EVENTS_OBSERVER"),
+		moduleBase: withModuleBase("EVENTS_OBSERVER", dependsOn("CACHE_MANAGER"), requires(device.LoggedOnNetwork)),
 	}
 }
 
-func (m *EventsObserver) Start(ctx context.Context) {
-	go m.Do(func() {
-		if !m.trySyncWithDeviceLifecycle(ctx, m.Start) {
-			return
+func (m *EventsObserver) Start(ctx context.Context) error {
+	var (
+		watchers = []func(context.Context) error{m.watchAssets, m.watchDevice, m.watchRequirements}
+		errCh    = make(chan error, len(watchers))
+	)
+
+	for i := range watchers {
+		watch := watchers[i]
+		device.Go(ctx, func() {
+			errCh <- watch(ctx)
+		})
+	}
+
+	// Failure of any watcher fails the whole module, so that all of them are restarted together:
+	for range watchers {
+		select {
+		case err := <-errCh:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
 		}
+	}
 
-		go m.watchAssets(ctx)
-		go m.watchDevice(ctx)
-		go m.watchRequirements(ctx)
-	})
+	return nil
 }
 
-func (m *EventsObserver) watchAssets(ctx context.Context) {
+func (m *EventsObserver) watchAssets(ctx context.Context) error {
 	if err := blockchain.Contracts.Assets.Subscribe(ctx, "*", func(asset *models.Asset, e string) error {
 		var (
 			changesPayload = events.AssetsChangedPayload{}
@@ -70,11 +86,13 @@ func (m *EventsObserver) watchAssets(ctx context.Context) {
 		eventdriver.EmitEvent(ctx, events.AssetsChanged, changesPayload)
 		return nil
 	}); err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to subscribe to assets changes on network"))
+		return errors.Wrap(err, "failed to subscribe to assets changes on network")
 	}
+
+	return nil
 }
 
-func (m *EventsObserver) watchDevice(ctx context.Context) {
+func (m *EventsObserver) watchDevice(ctx context.Context) error {
 	if err := blockchain.Contracts.Devices.Subscribe(ctx, "*", func(dev *models.Device, e string) error {
 		if dev.ID != m.ID() {
 			return nil
@@ -95,11 +113,13 @@ func (m *EventsObserver) watchDevice(ctx context.Context) {
 
 		return nil
 	}); err != nil {
-		shared.Logger.Fatal(errors.Wrap(err, "failed to subscribe to device changes on network"))
+		return errors.Wrap(err, "failed to subscribe to device changes on network")
 	}
+
+	return nil
 }
 
-func (m *EventsObserver) watchRequirements(ctx context.Context) {
+func (m *EventsObserver) watchRequirements(ctx context.Context) error {
 	if err := blockchain.Contracts.Requirements.Subscribe(ctx, "*",
 		func(req *models.Requirements, e string) error {
 			switch e {
@@ -133,8 +153,10 @@ func (m *EventsObserver) watchRequirements(ctx context.Context) {
 
 			return nil
 		}); err != nil {
-		shared.Logger.Fatal(errors.Wrap(err, "failed to subscribe to requirements changes on network"))
+		return errors.Wrap(err, "failed to subscribe to requirements changes on network")
 	}
+
+	return nil
 }
 
 func (m *EventsObserver) actOnDeviceUpdates(ctx context.Context, updated *models.Device) {
@@ -27,7 +27,7 @@ type FailoverHandler struct {
 // WithFailoverHandler can be used to setup FailoverHandler logical device.Module onto the device.Device.
 func WithFailoverHandler() device.Module {
 	return &FailoverHandler{
-		moduleBase: withModuleBase("FAILOVER_HANDLER"),
+		moduleBase: withModuleBase("FAILOVER_HANDLER", requires(device.BlockchainConnected)),
 		ctx: context.Background(),
 	}
 }
@@ -40,24 +40,39 @@ func (m *FailoverHandler) Setup(device *device.Device) error {
 	return m.moduleBase.Setup(device)
 }
 
-func (m *FailoverHandler) Start(ctx context.Context) {
+func (m *FailoverHandler) Start(ctx context.Context) error {
 	m.ctx = ctx
-	go m.Do(func() {
-		// Listen to metric readings failures
-		eventdriver.SubscribeHandler(events.MetricReadingsPostFailed, func(ctx context.Context, v interface{}) error {
-			if payload, ok := v.(events.MetricReadingsPostFailedPayload); ok {
-				m.handleFailedToPostReadings(payload.MetricReadings)
-				return nil
-			}
-
-			return eventdriver.ErrIncorrectPayload
-		})
+	// Listen to metric readings failures
+	m.subscribe(ctx, events.MetricReadingsPostFailed, func(ctx context.Context, v interface{}) error {
+		if payload, ok := v.(events.MetricReadingsPostFailedPayload); ok {
+			m.handleFailedToPostReadings(payload.MetricReadings)
+			return nil
+		}
 
-		// Try post leftover readings in cache
-		m.tryRepostCachedReadings()
+		return eventdriver.ErrIncorrectPayload
 	})
+
+	// Try post leftover readings in cache
+	m.tryRepostCachedReadings()
+
+	return m.untilDone(ctx)
 }
 
+// Close makes last attempt to post cached readings before device is shut down, the rest stays persisted.
+// It is done synchronously within device shutdown sequence, so that it is completed before storage is closed.
+func (m *FailoverHandler) Close() error {
+	if m.pingTimer != nil {
+		m.pingTimer.Stop()
+	}
+
+	if posted, networkDown := repostCachedReadings(context.Background()); networkDown {
+		shared.Logger.Warningf("Network is down on shutdown, cached readings will be posted on the next startup")
+	} else if posted != 0 {
+		shared.Logger.Infof("Posted %d cached readings on shutdown", posted)
+	}
+
+	return m.moduleBase.Close()
+}
 
 func (m *FailoverHandler) handleFailedToPostReadings(readings models.MetricReadings) {
 	m.pingNetworkConnection()
@@ -90,10 +105,18 @@ func (m *FailoverHandler) pingNetworkConnection() {
 
 // tryRepostCachedReadings makes attempt to repost cached during network absence sensor readings data.
 func (m *FailoverHandler) tryRepostCachedReadings() {
-	storage.IterateOverCachedReadings(m.ctx, func(key string, record models.MetricReadings) (toBreak bool, err error) {
+	if _, networkDown := repostCachedReadings(m.ctx); networkDown {
+		m.pingNetworkConnection()
+	}
+}
+
+// repostCachedReadings posts readings cached during network absence, removing posted ones from cache.
+// Returns number of posted records and whether posting was stopped due to network being still down.
+func repostCachedReadings(ctx context.Context) (posted int, networkDown bool) {
+	storage.IterateOverCachedReadings(ctx, func(key string, record models.MetricReadings) (toBreak bool, err error) {
 		if err = blockchain.Contracts.Readings.Post(record); err != nil {
 			if detectNetworkAbsence(err) {
-				m.pingNetworkConnection()
+				networkDown = true
 				shared.Logger.Debug("Network connection is still down - stop iterating sequence")
 
 				return true, nil
@@ -102,10 +125,13 @@ func (m *FailoverHandler) tryRepostCachedReadings() {
 			return false, err
 		}
 
+		posted++
 		shared.Logger.Debugf("Successfully posted cached readings for key: %s => %s", key, utils.Prettify(record))
 
 		return false, nil
 	}, true)
+
+	return
 }
 
 func (m *FailoverHandler) ping(t *time.Timer, onPong func()) {
@@ -3,6 +3,7 @@ package modules
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,7 +13,10 @@ import (
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-iot/controllers/device"
 	"github.com/timoth-y/chainmetric-iot/controllers/gui"
+	"github.com/timoth-y/chainmetric-iot/drivers/input"
+	"github.com/timoth-y/chainmetric-iot/model/config"
 	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/network/localnet"
 	"github.com/timoth-y/chainmetric-iot/shared"
 	"github.com/timoth-y/go-eventdriver"
 )
@@ -22,16 +26,25 @@ type GUIRenderer struct {
 	moduleBase
 	viewLock *sync.Mutex
 	timeoutLock *sync.Mutex
+	readingsLock *sync.Mutex
 
+	config config.GUIConfig
 	requestsThroughput []float64
+	latestReadings map[models.Metric]float64
+	assetsReadings map[string]map[models.Metric]float64
+	assetIndex int
+	updateInterval time.Duration
 }
 
 // WithGUIRenderer can be used to setup GUIRenderer logical device.Module onto the device.Device.
 func WithGUIRenderer() device.Module {
 	return &GUIRenderer{
-		moduleBase: withModuleBase("GUI_RENDERER"),
+		moduleBase: withModuleBase("GUI_RENDERER", requires(device.LoggedOnNetwork)),
 		viewLock: &sync.Mutex{},
 		timeoutLock: &sync.Mutex{},
+		readingsLock: &sync.Mutex{},
+		latestReadings: make(map[models.Metric]float64),
+		assetsReadings: make(map[string]map[models.Metric]float64),
 	}
 }
 
@@ -40,65 +53,128 @@ func (m *GUIRenderer) Setup(device *device.Device) error {
 		return errors.New("module won't work without display available")
 	}
 
+	if err := m.unmarshalConfig("gui", &m.config); err != nil {
+		return errors.Wrap(err, "failed to parse GUI config")
+	}
+
+	if m.config.AssetCycleInterval <= 0 {
+		return errors.New("GUI asset cycle interval must be positive")
+	}
+
 	if err := m.moduleBase.Setup(device); err != nil {
 		return err
 	}
 
 	m.requestsThroughput = append(m.requestsThroughput, 0)
+	m.updateInterval = viper.GetDuration("device.gui_update_interval")
 
 	return nil
 }
 
-func (m *GUIRenderer) Start(ctx context.Context) {
-	go m.Do(func() {
-		if !m.trySyncWithDeviceLifecycle(ctx, m.Start) {
-			return
-		}
+func (m *GUIRenderer) Start(ctx context.Context) error {
+	// Act on each new handled request to update device throughput and live values:
+	m.subscribe(ctx, events.RequestHandled, func(_ context.Context, v interface{}) error {
+		m.requestsThroughput[len(m.requestsThroughput) - 1]++
 
-		// Act on each new handled request to update device throughput:
-		eventdriver.SubscribeHandler(events.RequestHandled, func(_ context.Context, _ interface{}) error {
-			m.requestsThroughput[len(m.requestsThroughput) - 1]++
-			return nil
-		})
+		if payload, ok := v.(events.RequestHandledPayload); ok {
+			m.readingsLock.Lock()
+			if _, ok := m.assetsReadings[payload.AssetID]; !ok {
+				m.assetsReadings[payload.AssetID] = make(map[models.Metric]float64)
+			}
 
-		// Act on changes sensors pool to view hotswap notification:
-		eventdriver.SubscribeHandler(events.SensorsRegisterChanged, func(_ context.Context, v interface{}) error {
-			if payload, ok := v.(events.SensorsRegisterChangedPayload); ok {
-				m.renderHotswapNotification(payload)
-				return nil
+			for metric, value := range payload.Readings {
+				m.latestReadings[metric] = value
+				m.assetsReadings[payload.AssetID][metric] = value
 			}
+			m.readingsLock.Unlock()
+		}
 
-			return eventdriver.ErrIncorrectPayload
-		})
+		return nil
+	})
+
+	// Act on changes sensors pool to view hotswap notification:
+	m.subscribe(ctx, events.SensorsRegisterChanged, func(_ context.Context, v interface{}) error {
+		if payload, ok := v.(events.SensorsRegisterChangedPayload); ok {
+			m.renderHotswapNotification(payload)
+			return nil
+		}
 
-		m.renderStatsUI(true)
-		m.renderLoop(ctx)
+		return eventdriver.ErrIncorrectPayload
 	})
+
+	gui.SetScreens(m.screens(ctx)...)
+
+	if m.config.Buttons.Enabled {
+		m.listenButtons(ctx)
+	}
+
+	m.renderScreen()
+	m.tickThroughput()
+	m.renderLoop(ctx)
+
+	return nil
 }
 
 func (m *GUIRenderer) renderLoop(ctx context.Context) {
 	var (
-		ticker      = time.NewTicker(viper.GetDuration("device.gui_update_interval"))
-		hotswapCh   = eventdriver.SubscribeChannel(events.SensorsRegisterChanged)
-		bluetoothCh = eventdriver.SubscribeChannel(events.BluetoothPairingStarted)
-		locationCh  = eventdriver.SubscribeChannel(events.LocationUpdateReceived)
+		ticker      = time.NewTicker(m.updateInterval)
+		idleTicker  = time.NewTicker(time.Second)
+		cycleTicker = time.NewTicker(m.config.AssetCycleInterval)
+		hotswapCh   = m.subscribeChannel(ctx, events.SensorsRegisterChanged)
+		bluetoothCh = m.subscribeChannel(ctx, events.BluetoothPairingStarted)
+		locationCh  = m.subscribeChannel(ctx, events.LocationUpdateReceived)
+		calibrationCh = m.subscribeChannel(ctx, events.SensorsCalibrationStarted)
+		profileCh   = m.subscribeChannel(ctx, events.PowerProfileChanged)
+		pausedCh    = m.subscribeChannel(ctx, events.DevicePaused)
+		resumedCh   = m.subscribeChannel(ctx, events.DeviceResumed)
 	)
 
 LOOP:
 	for {
 		select {
 		case <- ticker.C:
-			m.renderStatsUI(true)
+			m.renderScreen()
+			m.tickThroughput()
+		case <- idleTicker.C:
+			if gui.ResetIfIdle(m.config.IdleTimeout) {
+				m.renderScreen()
+			}
+		case v := <- profileCh:
+			if payload, ok := v.(events.PowerProfileChangedPayload); ok {
+				m.viewLock.Lock()
+				m.updateInterval = payload.Settings.GUIUpdateInterval
+				m.viewLock.Unlock()
+
+				ticker.Reset(payload.Settings.GUIUpdateInterval)
+			}
+		case <- cycleTicker.C:
+			if screen, ok := gui.CurrentScreen(); ok && screen.Name == "assets" && m.cycleAsset() {
+				m.renderScreen()
+			}
 		case v := <- hotswapCh:
 			if payload, ok := v.(events.SensorsRegisterChangedPayload); ok {
 				m.decorateWithNotificationTimeout(func() {
 					m.renderHotswapNotification(payload)
 				}, 6 * time.Second)
 			}
+		case <- pausedCh:
+			m.decorateWithNotificationTimeout(func() {
+				gui.RenderWarningMsg("Device paused")
+			}, 6 * time.Second)
+		case <- resumedCh:
+			m.decorateWithNotificationTimeout(func() {
+				gui.RenderSuccessMsg("Device resumed")
+			}, 6 * time.Second)
 		case <- bluetoothCh:
 			m.decorateWithNotificationTimeout(func() {
 				gui.RenderTextWithIcon("Bluetooth pairing started...", "bluetooth")
 			}, 6 * time.Second)
+		case v := <- calibrationCh:
+			if duration, ok := v.(time.Duration); ok {
+				m.decorateWithNotificationTimeout(func() {
+					gui.RenderTextWithIcon("Calibrating sensors:\nrotate device in all directions", "warning")
+				}, duration)
+			}
 		case v := <- locationCh:
 			if payload, ok := v.(models.Location); ok {
 				m.decorateWithNotificationTimeout(func() {
@@ -115,32 +191,255 @@ LOOP:
 	}
 }
 
-func (m *GUIRenderer) renderStatsUI(reread bool) {
+// screens defines GUI pages available for navigation, where the overview is the home one.
+func (m *GUIRenderer) screens(ctx context.Context) []gui.Screen {
+	return []gui.Screen{
+		{
+			Name:   "overview",
+			Render: m.renderStatsUI,
+			Action: func() { m.startPairing(ctx) },
+		},
+		{
+			Name:   "metrics",
+			Render: m.renderMetricsUI,
+		},
+		{
+			Name:   "assets",
+			Render: m.renderAssetsUI,
+		},
+		{
+			Name:   "network",
+			Render: m.renderNetworkUI,
+			Action: func() { m.startPairing(ctx) },
+		},
+		{
+			Name:   "sensors",
+			Render: m.renderSensorsUI,
+		},
+		{
+			Name:   "registration",
+			Render: func() { gui.RenderQRCode(m.Specs().Encode()) },
+		},
+	}
+}
+
+// renderScreen displays currently selected GUI screen.
+func (m *GUIRenderer) renderScreen() {
+	m.viewLock.Lock()
+	defer m.viewLock.Unlock()
+
+	gui.RenderCurrentScreen()
+}
+
+// tickThroughput starts counting handled requests for the next GUI update interval.
+func (m *GUIRenderer) tickThroughput() {
+	m.requestsThroughput = append(m.requestsThroughput, 0)
+}
+
+func (m *GUIRenderer) renderStatsUI() {
 	var (
 		builder  = strings.Builder{}
-		interval = viper.GetDuration("device.gui_update_interval")
+		interval = m.updateInterval
 		throughput []float64
 	)
 
-	m.viewLock.Lock()
-	defer m.viewLock.Unlock()
-
 	for _, count := range m.requestsThroughput {
 		throughput = append(throughput, count * 60 / interval.Seconds())
 	}
 
 	builder.WriteString(fmt.Sprintf("IP: %s\n", m.Specs().IPAddress))
 	builder.WriteString(fmt.Sprintf("Supported: %d metrics\n", len(m.Specs().Supports)))
-	builder.WriteString(fmt.Sprintf("Thoughput: %d requests\\min",
-		int(throughput[len(m.requestsThroughput) - 1]),
-	))
+	if m.IsPaused() {
+		builder.WriteString("Paused: sensors in standby")
+	} else {
+		builder.WriteString(fmt.Sprintf("Thoughput: %d requests\\min",
+			int(throughput[len(m.requestsThroughput) - 1]),
+		))
+	}
 
 	gui.SetBatteryLevel(m.Battery().Level)
 	gui.RenderWithChart(builder.String(), m.requestsThroughput...)
+}
+
+func (m *GUIRenderer) renderMetricsUI() {
+	var lines []string
+
+	m.readingsLock.Lock()
+	for metric, value := range m.latestReadings {
+		lines = append(lines, fmt.Sprintf("%s: %.2f", metric, value))
+	}
+	m.readingsLock.Unlock()
+
+	sort.Strings(lines)
+
+	gui.RenderList("Live values", lines...)
+}
+
+// renderAssetsUI displays latest readings of the currently cycled asset
+// along with markers showing whether they satisfy requirements cached for it.
+func (m *GUIRenderer) renderAssetsUI() {
+	var (
+		assets = m.GetCachedAssets()
+		rows   []gui.Reading
+	)
+
+	if len(assets) == 0 {
+		gui.RenderReadings("Assets")
+		return
+	}
+
+	sort.Strings(assets)
+
+	m.readingsLock.Lock()
+	defer m.readingsLock.Unlock()
+
+	var (
+		index    = m.assetIndex % len(assets)
+		assetID  = assets[index]
+		title    = m.GetCachedAssetName(assetID)
+		readings = m.assetsReadings[assetID]
+		limits   = make(models.RequirementsMap)
+	)
+
+	for _, request := range m.GetCachedRequirementsFor(assetID) {
+		for metric, limit := range request.Limits {
+			limits[metric] = limit
+		}
+	}
+
+	for _, metric := range limits.Metrics() {
+		var (
+			limit = limits[metric]
+			row   = gui.Reading{Label: string(metric), Value: "n/a"}
+		)
+
+		if value, ok := readings[metric]; ok {
+			row.Value = fmt.Sprintf("%.2f", value)
+			row.Status = gui.ReadingInRange
+
+			if value < limit.MinLimit || value > limit.MaxLimit {
+				row.Status = gui.ReadingOutOfRange
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Label < rows[j].Label
+	})
+
+	if len(assets) > 1 {
+		title = fmt.Sprintf("%s (%d/%d)", title, index + 1, len(assets))
+	}
+
+	gui.RenderReadings(title, rows...)
+}
+
+// cycleAsset switches assets screen to the next asset and reports whether there is more than one to cycle through.
+func (m *GUIRenderer) cycleAsset() bool {
+	m.readingsLock.Lock()
+	defer m.readingsLock.Unlock()
+
+	m.assetIndex++
+
+	return len(m.GetCachedAssets()) > 1
+}
+
+func (m *GUIRenderer) renderNetworkUI() {
+	var (
+		specs = m.Specs()
+		ledger = "offline"
+		bluetooth = "disabled"
+	)
 
-	if reread {
-		m.requestsThroughput = append(m.requestsThroughput, 0)
+	if m.IsLoggedToNetwork() {
+		ledger = "online"
 	}
+
+	if localnet.Available() {
+		bluetooth = "enabled"
+	} else if viper.GetBool("bluetooth.enabled") {
+		bluetooth = "suspended"
+	}
+
+	gui.RenderList("Network",
+		fmt.Sprintf("IP: %s", specs.IPAddress),
+		fmt.Sprintf("Host: %s", specs.Hostname),
+		fmt.Sprintf("State: %s", m.State()),
+		fmt.Sprintf("Ledger: %s", ledger),
+		fmt.Sprintf("Bluetooth: %s", bluetooth),
+	)
+}
+
+func (m *GUIRenderer) renderSensorsUI() {
+	var ids []string
+
+	for _, sn := range m.RegisteredSensors().ToList() {
+		ids = append(ids, sn.ID())
+	}
+
+	sort.Strings(ids)
+
+	gui.RenderList("Sensors", ids...)
+}
+
+// listenButtons starts routines handling physical buttons interactions for GUI navigation:
+// next and previous buttons switch screens, long press of the next one returns to the overview,
+// select button refreshes current screen and performs its action on long press.
+func (m *GUIRenderer) listenButtons(ctx context.Context) {
+	var (
+		cfg = m.config.Buttons
+		handlers = map[int]func(input.Action){
+			cfg.NextPin: func(action input.Action) {
+				if action == input.LongPress {
+					gui.HomeScreen()
+				} else {
+					gui.NextScreen()
+				}
+			},
+			cfg.PrevPin: func(_ input.Action) {
+				gui.PreviousScreen()
+			},
+			cfg.SelectPin: func(action input.Action) {
+				if action == input.LongPress {
+					gui.TriggerScreenAction()
+				}
+			},
+		}
+	)
+
+	for pin, handler := range handlers {
+		if pin == 0 {
+			continue
+		}
+
+		var (
+			button = input.NewButton(fmt.Sprintf("GPIO%d", pin), pin, cfg.Debounce, cfg.LongPress)
+			handle = handler
+		)
+
+		if err := button.Init(); err != nil {
+			shared.Logger.Error(err)
+			continue
+		}
+
+		go button.Listen(ctx, func(action input.Action) {
+			handle(action)
+			m.renderScreen()
+		})
+	}
+}
+
+// startPairing starts Bluetooth pairing, so that device could be discovered by mobile application.
+func (m *GUIRenderer) startPairing(ctx context.Context) {
+	eventdriver.EmitEvent(ctx, events.BluetoothPairingStarted, nil)
+
+	go func() {
+		if err := localnet.Pair(ctx); err != nil && errors.Cause(err) != context.DeadlineExceeded {
+			shared.Logger.Error(errors.Wrap(err, "failed to start Bluetooth pairing from GUI"))
+		}
+	}()
 }
 
 func (m *GUIRenderer) renderHotswapNotification(event events.SensorsRegisterChangedPayload) {
@@ -181,6 +480,6 @@ func (m *GUIRenderer) decorateWithNotificationTimeout(renderFunc func(), d time.
 		defer m.timeoutLock.Unlock()
 
 		time.Sleep(d)
-		m.renderStatsUI(false)
+		m.renderScreen()
 	}()
 }
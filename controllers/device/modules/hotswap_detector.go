@@ -2,6 +2,7 @@ package modules
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,11 +16,12 @@ import (
 	"github.com/timoth-y/go-eventdriver"
 )
 
+// sensorsScanLock prevents concurrent scans, e.g. by HotswapDetector routine and remote command.
+var sensorsScanLock = &sync.Mutex{}
+
 // HotswapDetector implements device.Module ofr detecting changes in connected to device.Device sensors.
 type HotswapDetector struct {
 	moduleBase
-
-	detectedI2Cs io.I2CDetectResults
 }
 
 // WithHotswapDetector can be used to setup HotswapDetector logical device.Module onto the device.Device.
@@ -29,49 +31,64 @@ func WithHotswapDetector() device.Module {
 	}
 }
 
-func (m *HotswapDetector) Start(ctx context.Context) {
-	go m.Do(func() {
-		var (
-			interval = viper.GetDuration("device.hotswap_detect_interval")
-			startTime  time.Time
-		)
-
-	LOOP:
-		for {
-			startTime = time.Now()
-
-			if err := m.handleHotswap(ctx); err != nil {
-				shared.Logger.Error(errors.Wrap(err, "failed to handle hotswap"))
-			}
-
-			select {
-			case <- time.After(interval - time.Since(startTime)):
-			case <- ctx.Done():
-				shared.Logger.Debug("Hotswap detector module routine ended")
-				break LOOP
-			}
+func (m *HotswapDetector) Start(ctx context.Context) error {
+	var (
+		interval = viper.GetDuration("device.hotswap_detect_interval")
+		startTime  time.Time
+		profileCh = m.subscribeChannel(ctx, events.PowerProfileChanged)
+	)
+
+LOOP:
+	for {
+		startTime = time.Now()
+
+		if err := m.handleHotswap(ctx); err != nil {
+			shared.Logger.Error(errors.Wrap(err, "failed to handle hotswap"))
+		}
+
+		select {
+		case <- time.After(interval - time.Since(startTime)):
+		case v := <- profileCh:
+			if payload, ok := v.(events.PowerProfileChangedPayload); ok {
+				interval = payload.Settings.HotswapInterval
+			} // Sensors are scanned right away to apply new interval from now on.
+		case <- ctx.Done():
+			shared.Logger.Debug("Hotswap detector module routine ended")
+			break LOOP
 		}
-	})
+	}
+
+	return nil
 }
 
 func (m *HotswapDetector) handleHotswap(ctx context.Context) error {
+	detectSensorsChanges(ctx, m.Device)
+
+	return nil
+}
+
+// detectSensorsChanges scans device's periphery for attached and detached sensors
+// and applies found changes on the sensors register of the `dev`, notifying about them with events.SensorsRegisterChanged.
+func detectSensorsChanges(ctx context.Context, dev *device.Device) events.SensorsRegisterChangedPayload {
 	var (
 		detectedSensors = make(sensor.SensorsRegister)
-		registeredSensors = m.RegisteredSensors()
-		staticSensors = m.StaticSensors()
+		registeredSensors = dev.RegisteredSensors()
+		staticSensors = dev.StaticSensors()
 		payload = events.SensorsRegisterChangedPayload{}
 		isChanges bool
 	)
 
-	m.detectedI2Cs = io.ScanI2C(sensors.I2CAddressesRange(), sensors.LocateI2CSensor)
-	for _, devices := range m.detectedI2Cs {
+	sensorsScanLock.Lock()
+	defer sensorsScanLock.Unlock()
+
+	for _, devices := range io.ScanI2C(sensors.I2CAddressesRange(), sensors.LocateI2CSensor) {
 		for _, s := range devices {
 			detectedSensors[s.ID()] = s
 		}
 	}
 
 	for id := range registeredSensors {
-		if !detectedSensors.Exists(id) && !m.contains(staticSensors, id) {
+		if !detectedSensors.Exists(id) && !staticSensors.Exists(id) {
 			payload.Removed = append(payload.Removed, id)
 			isChanges = true
 			shared.Logger.Debugf("Hotswap: %s sensor was detached from the device", id)
@@ -88,13 +105,8 @@ func (m *HotswapDetector) handleHotswap(ctx context.Context) error {
 
 	if isChanges {
 		eventdriver.EmitEvent(ctx, events.SensorsRegisterChanged, payload)
-		m.UpdateSensorsRegister(payload.Added, payload.Removed)
+		dev.UpdateSensorsRegister(payload.Added, payload.Removed)
 	}
 
-	return nil
-}
-
-func (m *HotswapDetector) contains(register map[string]sensor.Sensor, id string) bool {
-	_, contains := register[id]
-	return contains
+	return payload
 }
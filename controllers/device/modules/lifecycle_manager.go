@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/skip2/go-qrcode"
 	"github.com/spf13/viper"
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-iot/controllers/device"
@@ -30,7 +29,7 @@ type LifecycleManager struct {
 // WithLifecycleManager can be used to setup LifecycleManager logical device.Module onto the device.Device.
 func WithLifecycleManager() device.Module {
 	return &LifecycleManager{
-		moduleBase: withModuleBase("LIFECYCLE_MANAGER"),
+		moduleBase: withModuleBase("LIFECYCLE_MANAGER", requires(device.BlockchainConnected)),
 	}
 }
 
@@ -54,18 +53,18 @@ func (m *LifecycleManager) Setup(device *device.Device) error {
 	return nil
 }
 
-func (m *LifecycleManager) Start(ctx context.Context) {
-	go m.Do(func() {
-		if id, is := isRegistered(); is {
-			m.logInNetwork(ctx, id)
-		} else {
-			m.proceedToDeviceRegistration(ctx)
-		}
+func (m *LifecycleManager) Start(ctx context.Context) error {
+	if id, is := isRegistered(); is {
+		m.logInNetwork(ctx, id)
+	} else {
+		m.proceedToDeviceRegistration(ctx)
+	}
 
-		eventdriver.SubscribeHandler(events.DeviceRemovedFromNetwork, func(_ context.Context, _ interface{}) error {
-			return errors.Wrap(m.resetDevice(true), "failed to reset device")
-		})
+	m.subscribe(ctx, events.DeviceRemovedFromNetwork, func(_ context.Context, _ interface{}) error {
+		return errors.Wrap(resetDevice(true), "failed to reset device")
 	})
+
+	return m.untilDone(ctx)
 }
 
 func (m *LifecycleManager) Close() error {
@@ -89,7 +88,7 @@ func (m *LifecycleManager) logInNetwork(ctx context.Context, id string) {
 			return
 		}
 
-		specs.State = models.DeviceOnline
+		specs.State = m.operationalState()
 
 		defer shared.MustExecute(func() error {
 			return m.SetSpecs(func(ds *model.DeviceSpecs) {
@@ -118,7 +117,7 @@ func (m *LifecycleManager) proceedToDeviceRegistration(ctx context.Context) {
 		return
 	 }
 
-	specs.State = models.DeviceOnline
+	specs.State = m.operationalState()
 
 	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration("device.register_timeout_duration"))
 
@@ -129,13 +128,12 @@ func (m *LifecycleManager) proceedToDeviceRegistration(ctx context.Context) {
 		}
 	}(ctx)
 
-	// Display registration payload as QR code:
+	// Display registration payload as QR code, on machines without display panel `virtual` driver can be used for that:
 	if gui.Available() {
 		shared.Logger.Debug("Rendering QR")
 		gui.RenderQRCode(specs.Encode())
 	} else {
-		// Alternative way to display registration QR code on Windows for debug purposes:
-		_ = qrcode.WriteFile(specs.Encode(), qrcode.Medium, 320, "qr.png")
+		shared.Logger.Warning("Registration QR code can't be displayed, consider using 'virtual' display driver")
 	}
 
 	if err := contract.Subscribe(ctx, "inserted", func(dev *models.Device, _ string) error {
@@ -215,7 +213,7 @@ func (m *LifecycleManager) storeIdentity(id string) error {
 
 // resetDevice resets device.Device by removing stored identity and all allocated resources.
 // Use `forceful` to specify that device must be reset since it has been removed from network.
-func (m *LifecycleManager) resetDevice(forceful bool) error {
+func resetDevice(forceful bool) error {
 	if !forceful {
 		id, is := isRegistered(); if !is {
 			return nil
@@ -230,11 +228,26 @@ func (m *LifecycleManager) resetDevice(forceful bool) error {
 		return errors.Wrap(err, "failed to remove device's identity file")
 	}
 
+	// Paused state belongs to the removed device, so it must not be inherited by the newly registered one:
+	if err := os.Remove(viper.GetString("device.pause_file_path")); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove device's pause file")
+	}
+
 	shared.Logger.Info("Device has been reset")
 
 	return nil
 }
 
+// operationalState determines state of the device once it's logged on network,
+// which is models.DevicePaused if device was paused before reboot.
+func (m *LifecycleManager) operationalState() models.DeviceState {
+	if m.IsPaused() {
+		return models.DevicePaused
+	}
+
+	return models.DeviceOnline
+}
+
 func (m *LifecycleManager) notifyOff() error {
 	if !m.IsLoggedToNetwork() {
 		return nil
@@ -25,20 +25,20 @@ func WithLocationManager() device.Module {
 	}
 }
 
-func (m *LocationManager) Start(ctx context.Context) {
-	go m.Do(func() {
-		if err := localnet.Channels.Geo.Subscribe(ctx, func(location models.Location) error {
-			if err := m.SetLocation(location); err != nil {
-				return err
-			}
+func (m *LocationManager) Start(ctx context.Context) error {
+	if err := localnet.Channels.Geo.Subscribe(ctx, func(location models.Location) error {
+		if err := m.SetLocation(location); err != nil {
+			return err
+		}
 
-			eventdriver.EmitEvent(ctx, events.LocationUpdateReceived, location)
+		eventdriver.EmitEvent(ctx, events.LocationUpdateReceived, location)
 
-			shared.Logger.Debugf("Device location was updated via Bluetooth tethering: %s", location.Name)
+		shared.Logger.Debugf("Device location was updated via Bluetooth tethering: %s", location.Name)
 
-			return nil
-		}); err != nil {
-			shared.Logger.Error(errors.Wrap(err, "failed to subscribe to geo channel"))
-		}
-	})
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "failed to subscribe to geo channel")
+	}
+
+	return nil
 }
@@ -2,11 +2,14 @@ package modules
 
 import (
 	"context"
-	"sync"
-	"time"
 
+	"github.com/pkg/errors"
+	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/chainmetric-core/utils"
 	"github.com/timoth-y/chainmetric-iot/controllers/device"
+	"github.com/timoth-y/chainmetric-iot/controllers/storage"
 	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/network/blockchain"
 	"github.com/timoth-y/chainmetric-iot/shared"
 	"github.com/timoth-y/go-eventdriver"
 )
@@ -14,29 +17,70 @@ import (
 // moduleBase implements base functionality of the device.Module.
 type moduleBase struct {
 	*device.Device
-	*sync.Once
 
-	mid string
+	mid          string
+	dependencies []string
+	conditions   []device.Condition
 }
 
+// moduleOption configures moduleBase of the device.Module.
+type moduleOption func(m *moduleBase)
+
 // withModuleBase can be used to embed moduleBase to device.Module implementation.
-func withModuleBase(mid string) moduleBase {
-	return moduleBase{
-		Once: &sync.Once{},
+func withModuleBase(mid string, options ...moduleOption) moduleBase {
+	m := moduleBase{
 		mid: mid,
 	}
+
+	for i := range options {
+		options[i](&m)
+	}
+
+	return m
+}
+
+// dependsOn declares modules by given `mids`, which must be started before the device.Module.
+func dependsOn(mids ...string) moduleOption {
+	return func(m *moduleBase) {
+		m.dependencies = append(m.dependencies, mids...)
+	}
+}
+
+// requires declares readiness `conditions`, which must be met before the device.Module is started.
+func requires(conditions ...device.Condition) moduleOption {
+	return func(m *moduleBase) {
+		m.conditions = append(m.conditions, conditions...)
+	}
 }
 
 func (m *moduleBase) MID() string {
 	return m.mid
 }
 
+func (m *moduleBase) Dependencies() []string {
+	return m.dependencies
+}
+
+func (m *moduleBase) Conditions() []device.Condition {
+	return m.conditions
+}
+
 func (m *moduleBase) Setup(device *device.Device) error {
 	m.Device = device
 
 	return nil
 }
 
+// unmarshalConfig decodes config `section` of the module into typed structure `v`
+// and overrides it with module's settings from `modules` config section.
+func (m *moduleBase) unmarshalConfig(section string, v interface{}) error {
+	if err := shared.UnmarshalFromConfig(section, v); err != nil {
+		return err
+	}
+
+	return device.UnmarshalModuleSettings(m.mid, v)
+}
+
 func (m *moduleBase) IsReady() bool {
 	return m.Device != nil
 }
@@ -47,55 +91,72 @@ func (m *moduleBase) Close() error {
 	return nil
 }
 
-// trySyncWithDeviceLifecycle attempts to synchronize device.Module execution with LifecycleManager module
-// by waiting for device to login on network for a limited period of time,
-// otherwise it will subscribe to events.DeviceLoggedOnNetwork and perform `postponedStart` when received one.
-func (m *moduleBase) trySyncWithDeviceLifecycle(
-	ctx context.Context,
-	postponedStart func(context.Context),
-) (synced bool) {
-	if !m.waitUntilDeviceLogged() {
-		m.Once = &sync.Once{}
-		var cancel context.CancelFunc
-		cancel = eventdriver.SubscribeHandler(events.DeviceLoggedOnNetwork, func(_ context.Context, _ interface{}) error {
-			postponedStart(ctx)
-			cancel()
-			return nil
-		})
-
-		shared.Logger.Infof("device.Module '%s' is awaiting notification for the device login", m.MID())
-		return false
-	}
-
-	return true
+// subscribe subscribes `handler` to the local `event` for the duration of module's run bound to `ctx`.
+// Panic in the handler is reported as run failure, so that module could be restarted by the supervisor.
+func (m *moduleBase) subscribe(ctx context.Context, event string, handler eventdriver.EventHandlerFunc) {
+	subscribeRun(ctx, event, func(ectx context.Context, v interface{}) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				device.Fail(ctx, errors.Errorf("panic in '%s' event handler: %v", event, r))
+			}
+		}()
+
+		return handler(ectx, v)
+	})
 }
 
-// waitUntilDeviceLogged checks whether the device.Device is logged on network with a specific intervals.
-func (m *moduleBase) waitUntilDeviceLogged() bool {
-	var attempts = 10
-	for attempts > 0 {
-		if m.IsLoggedToNetwork() {
-			return true
+// subscribeChannel subscribes to the local `event` for the duration of module's run bound to `ctx`
+// and redirects its payload to returned channel. Unlike eventdriver.SubscribeChannel,
+// it won't block events delivery once the run has ended.
+func (m *moduleBase) subscribeChannel(ctx context.Context, event string) <-chan interface{} {
+	var ch = make(chan interface{})
+
+	subscribeRun(ctx, event, func(_ context.Context, v interface{}) error {
+		select {
+		case ch <- v:
+		case <-ctx.Done():
 		}
 
-		time.Sleep(500 * time.Millisecond)
-		attempts--
-	}
+		return nil
+	})
 
-	return false
+	return ch
 }
 
-// waitUntilSensorsDetected checks whether the sensors detected with a specific intervals.
-func (m *moduleBase) waitUntilSensorsDetected() bool {
-	var attempts = 5
-	for attempts > 0 {
-		if m.RegisteredSensors().NotEmpty() {
-			return true
+// untilDone blocks until the context is done, which is used by modules operating on events only,
+// so that they are considered running by the supervisor.
+func (m *moduleBase) untilDone(ctx context.Context) error {
+	<-ctx.Done()
+
+	return nil
+}
+
+// postMetricReadings posts `record` on the blockchain ledger and reports whether it succeeded.
+// In case of the network absence events.MetricReadingsPostFailed is emitted, so that record could be posted later.
+// Once module's run bound to `ctx` is ended, e.g. device is being shut down, record is cached right away instead,
+// since the event won't be handled in time.
+func (m *moduleBase) postMetricReadings(ctx context.Context, record models.MetricReadings) bool {
+	if err := blockchain.Contracts.Readings.Post(record); err != nil {
+		if detectNetworkAbsence(err) {
+			if ctx.Err() != nil {
+				if err = storage.CacheReadings(record); err != nil {
+					shared.Logger.Error(errors.Wrap(err, "failed to cache readings on shutdown"))
+				}
+
+				return false
+			}
+
+			eventdriver.EmitEvent(ctx, events.MetricReadingsPostFailed, events.MetricReadingsPostFailedPayload{
+				MetricReadings: record,
+				Error: err,
+			})
+		} else {
+			shared.Logger.Error(errors.Wrapf(err, "failed to post readings with id %s%s", utils.Hash(record.AssetID),
+				utils.Hash(string(record.Encode()))))
 		}
 
-		time.Sleep(250 * time.Millisecond)
-		attempts--
+		return false
 	}
 
-	return false
+	return true
 }
@@ -0,0 +1,173 @@
+package modules
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/chainmetric-iot/controllers/device"
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/core/motion"
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/shared"
+	"github.com/timoth-y/go-eventdriver"
+)
+
+// MotionMonitor implements device.Module for continuous accelerometer-based motion analysis,
+// detecting tilt, shock, and free-fall events and posting them on the ledger for the assets in range.
+type MotionMonitor struct {
+	moduleBase
+
+	config    config.MotionConfig
+	detectors map[string]*motion.Detector
+	leased    map[string]sensor.AxesReader
+}
+
+// WithMotionMonitor can be used to setup MotionMonitor logical device.Module onto the device.Device.
+func WithMotionMonitor() device.Module {
+	return &MotionMonitor{
+		moduleBase: withModuleBase("MOTION_MONITOR", requires(device.LoggedOnNetwork)),
+		detectors:  make(map[string]*motion.Detector),
+		leased:     make(map[string]sensor.AxesReader),
+	}
+}
+
+func (m *MotionMonitor) Setup(device *device.Device) error {
+	if err := m.unmarshalConfig("motion", &m.config); err != nil {
+		return errors.Wrap(err, "failed to parse motion config")
+	}
+
+	return m.moduleBase.Setup(device)
+}
+
+func (m *MotionMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.config.SampleInterval)
+	defer ticker.Stop()
+	defer m.releaseAll()
+
+LOOP:
+	for {
+		select {
+		case <-ticker.C:
+			if m.IsPaused() {
+				// Baseline will be outdated once device is resumed, so it's collected over again:
+				for id := range m.detectors {
+					delete(m.detectors, id)
+				}
+
+				// Sensors aren't sampled while paused, so those are let to be closed:
+				m.releaseAll()
+
+				continue
+			}
+
+			m.sample(ctx)
+		case <-ctx.Done():
+			shared.Logger.Debug("Motion monitor module routine ended")
+			break LOOP
+		}
+	}
+
+	return nil
+}
+
+func (m *MotionMonitor) sample(ctx context.Context) {
+	var (
+		accelerometers = m.RegisteredSensors().Accelerometers()
+		present        = make(map[string]bool, len(accelerometers))
+	)
+
+	for _, sn := range accelerometers {
+		present[sn.ID()] = true
+
+		if _, leased := m.leased[sn.ID()]; !leased {
+			// Sensor is held leased while being monitored, so that engine won't close it on stand-by:
+			if err := sensor.Acquire(sn); err != nil {
+				shared.Logger.Error(errors.Wrapf(err, "failed to initialize %s sensor for motion analysis", sn.ID()))
+				continue
+			}
+
+			m.leased[sn.ID()] = sn
+		}
+
+		v, err := sn.ReadAxes()
+		if err != nil {
+			continue
+		}
+
+		detector, exists := m.detectors[sn.ID()]
+		if !exists {
+			detector = motion.NewDetector(sn.ID(), m.config)
+			m.detectors[sn.ID()] = detector
+		}
+
+		for _, event := range detector.Process(v, time.Now()) {
+			m.handleEvent(ctx, event)
+		}
+	}
+
+	// Baseline of the detached sensor is no longer relevant:
+	for id := range m.detectors {
+		if !present[id] {
+			delete(m.detectors, id)
+		}
+	}
+
+	for id, sn := range m.leased {
+		if !present[id] {
+			sensor.Release(sn)
+			delete(m.leased, id)
+		}
+	}
+}
+
+// releaseAll returns leases of all monitored sensors and closes ones no longer in use by others.
+func (m *MotionMonitor) releaseAll() {
+	for id, sn := range m.leased {
+		sensor.Release(sn)
+		delete(m.leased, id)
+
+		if _, err := sensor.CloseIdle(sn); err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to close connection to '%s' sensor", id))
+		}
+	}
+}
+
+func (m *MotionMonitor) handleEvent(ctx context.Context, event motion.Event) {
+	shared.Logger.Infof("Motion: %s (sensor: %s, axes: %+v)", event.Describe(), event.SensorID, event.Vector)
+
+	eventdriver.EmitEvent(ctx, events.MotionDetected, events.MotionDetectedPayload{
+		Event: event,
+	})
+
+	for _, assetID := range m.GetCachedAssets() {
+		m.postMetricReadings(ctx, models.MetricReadings{
+			AssetID:   assetID,
+			DeviceID:  m.ID(),
+			Timestamp: event.Timestamp,
+			Values:    motionReadings(event),
+		})
+	}
+}
+
+func motionReadings(event motion.Event) map[models.Metric]float64 {
+	readings := map[models.Metric]float64{
+		model.MotionAxisX: event.Vector.X,
+		model.MotionAxisY: event.Vector.Y,
+		model.MotionAxisZ: event.Vector.Z,
+	}
+
+	switch event.Type {
+	case motion.Tilt:
+		readings[model.MotionTilt] = event.Value
+	case motion.Shock:
+		readings[model.MotionShock] = event.Value
+	case motion.FreeFall:
+		readings[model.MotionFreeFall] = event.Value
+	}
+
+	return readings
+}
@@ -2,78 +2,424 @@ package modules
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-iot/controllers/device"
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
 	"github.com/timoth-y/chainmetric-iot/drivers/power"
+	"github.com/timoth-y/chainmetric-iot/drivers/sensors"
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/network/localnet"
 	"github.com/timoth-y/chainmetric-iot/shared"
+	"github.com/timoth-y/go-eventdriver"
 )
 
 // PowerManager implements device.Module for device.Device battery management.
 type PowerManager struct {
 	moduleBase
 
-	ups  *power.UPSController
+	config    config.PowerConfig
+	ups       *power.UPSController
+	estimator *power.RateEstimator
+	plugged   bool
+	profile   model.PowerProfile
+	depleted  int
+	meter     sensor.PowerMeter
+	energy    *power.EnergyCounter
+	readings  int64
 }
 
+// profileHysteresis defines battery level margin in percents, by which it must rise above the threshold
+// for switching back to less restrictive power profile, so that profiles won't flap due to level fluctuations.
+const profileHysteresis = 3
+
 // WithPowerManager can be used to setup PowerManager logical device.Module onto the device.Device.
 func WithPowerManager() device.Module {
 	return &PowerManager{
 		moduleBase: withModuleBase("POWER_MANAGER"),
-		ups: power.NewUPSController(),
 	}
 }
 
 
 func (m *PowerManager) Setup(device *device.Device) error {
+	if err := m.unmarshalConfig("power", &m.config); err != nil {
+		return errors.Wrap(err, "failed to parse power config")
+	}
+
+	m.ups = power.NewUPSController(m.config.AlertThreshold, m.config.AlertPin)
+	m.estimator = power.NewRateEstimator(m.config.EstimationWindow)
+
 	if err := m.ups.Init(); err != nil {
 		return errors.Wrap(err, "failed to initialize ups controller driver")
 	}
 
+	if version, err := m.ups.Version(); err == nil {
+		shared.Logger.Debugf("Fuel gauge version: 0x%04X", version)
+	}
+
+	// Power meter is optional, so the module can still operate without consumption statistics:
+	if m.config.Meter.Enabled {
+		if err := m.setupMeter(); err != nil {
+			shared.Logger.Warning(errors.Wrap(err, "power consumption won't be measured"))
+		}
+	}
+
 	return m.moduleBase.Setup(device)
 }
 
-func (m *PowerManager) Start(ctx context.Context) {
-	go m.Do(func() {
-		var (
-			startTime  time.Time
-			interval = viper.GetDuration("device.battery_check_interval")
-		)
+// setupMeter initializes INA219 power monitor measuring device's own power consumption.
+func (m *PowerManager) setupMeter() error {
+	if m.config.Meter.SampleInterval <= 0 {
+		return errors.New("power meter sample interval must be positive")
+	}
+
+	meter, ok := sensors.NewINA219(m.config.Meter.Address, m.config.Meter.Bus).(sensor.PowerMeter)
+	if !ok {
+		return errors.New("INA219 driver isn't capable of power measurement")
+	}
+
+	if !meter.Verify() {
+		return errors.Errorf("INA219 power monitor not found at 0x%X address", m.config.Meter.Address)
+	}
+
+	if err := meter.Init(); err != nil {
+		return err
+	}
+
+	m.meter = meter
+	m.energy = power.NewEnergyCounter()
+
+	return nil
+}
+
+func (m *PowerManager) Start(ctx context.Context) error {
+	var (
+		startTime  time.Time
+		interval = viper.GetDuration("device.battery_check_interval")
+		alertCh  = m.listenAlert(ctx)
+	)
+
+	m.sampleConsumption(ctx)
+
+LOOP:
+	for {
+		select {
+		case <-time.After(interval - time.Since(startTime)):
+		case <-alertCh:
+			shared.Logger.Warning("Low battery alert signaled by fuel gauge")
+		case <- ctx.Done():
+			shared.Logger.Debug("Power management module routine ended")
+			break LOOP
+		}
+
+		startTime = time.Now()
+
+		status, err := m.readStatus()
+		if err != nil {
+			shared.Logger.Error(err)
+			continue
+		}
+
+		if m.detectDepletion(status) {
+			m.requestShutdown(ctx, status)
+			break LOOP
+		}
+
+		if status.Level == 0 {
+			continue
+		} // Fuel gauge chip is not yet ready.
+
+		if err = m.SetBattery(status.DeviceBattery); err != nil {
+			shared.Logger.Error(err)
+		}
+
+		eventdriver.EmitEvent(ctx, events.BatteryStatusUpdated, events.BatteryStatusUpdatedPayload{
+			BatteryStatus: status,
+		})
+
+		m.applyPowerPolicy(ctx, status)
+
+		shared.Logger.Debugf("Device battery: %d%% left, %.0f mV, %.2f%%/h",
+			status.Level, status.Voltage, status.Rate)
+
+		if status.PowerDraw > 0 {
+			shared.Logger.Debugf("Device consumption: %.0f mW, %.1f mJ per reading",
+				status.PowerDraw, status.EnergyPerReading)
+		}
+	}
+
+	return nil
+}
+
+func (m *PowerManager) Close() error {
+	if m.meter != nil {
+		if err := m.meter.Close(); err != nil {
+			shared.Logger.Error(errors.Wrap(err, "failed to close power meter"))
+		}
+	}
+
+	return m.moduleBase.Close()
+}
+
+// readStatus reads battery telemetry from fuel gauge and estimates charge or discharge time.
+func (m *PowerManager) readStatus() (model.BatteryStatus, error) {
+	soc, err := m.ups.StateOfCharge()
+	if err != nil {
+		return model.BatteryStatus{}, err
+	}
+
+	voltage, err := m.ups.BatteryVoltage()
+	if err != nil {
+		return model.BatteryStatus{}, err
+	}
+
+	level, err := m.ups.BatteryLevel()
+	if err != nil {
+		return model.BatteryStatus{}, err
+	}
+
+	alert, err := m.ups.Alert()
+	if err != nil {
+		return model.BatteryStatus{}, err
+	}
+
+	// Alert must be cleared, so that fuel gauge could raise it again:
+	if alert {
+		shared.Logger.Warningf("Battery level is below %d%% alert threshold", m.config.AlertThreshold)
+
+		if err = m.ups.ClearAlert(); err != nil {
+			shared.Logger.Error(err)
+		}
+	}
+
+	var plugged = m.ups.IsPlugged()
+
+	// Charge and discharge rates are unrelated, so estimation must start over:
+	if plugged != m.plugged {
+		m.estimator.Reset()
+		m.plugged = plugged
+	}
+
+	m.estimator.Add(soc, time.Now())
+
+	var status = model.BatteryStatus{
+		DeviceBattery: models.DeviceBattery{
+			Level:     level,
+			PluggedIn: plugged,
+		},
+		Voltage: voltage,
+		Alert:   alert,
+	}
+
+	status.Rate, _ = m.estimator.Rate()
+	status.TimeToEmpty, _ = m.estimator.TimeToEmpty(soc)
+	status.TimeToFull, _ = m.estimator.TimeToFull(soc)
+
+	m.measureConsumption(&status)
+
+	return status, nil
+}
+
+// sampleConsumption starts routine periodically sampling device power consumption from the power meter,
+// and counts handled readings requests, so that energy spent per reading could be determined.
+func (m *PowerManager) sampleConsumption(ctx context.Context) {
+	if m.meter == nil {
+		return
+	}
+
+	m.subscribe(ctx, events.RequestHandled, func(_ context.Context, _ interface{}) error {
+		atomic.AddInt64(&m.readings, 1)
+		return nil
+	})
+
+	device.Go(ctx, func() {
+		var ticker = time.NewTicker(m.config.Meter.SampleInterval)
+		defer ticker.Stop()
 
-	LOOP:
 		for {
 			select {
-			case <-time.After(interval - time.Since(startTime)):
-			case <- ctx.Done():
-				shared.Logger.Debug("Power management module routine ended")
-				break LOOP
+			case at := <-ticker.C:
+				reading, err := m.meter.ReadPower()
+				if err != nil {
+					shared.Logger.Debug(err)
+					continue
+				}
+
+				m.energy.Add(reading.Power, at)
+			case <-ctx.Done():
+				return
 			}
+		}
+	})
+}
 
-			startTime = time.Now()
+// measureConsumption fills `status` with average power draw and energy per handled reading
+// since the previous status, leaving them empty if power meter isn't available.
+func (m *PowerManager) measureConsumption(status *model.BatteryStatus) {
+	if m.meter == nil {
+		return
+	}
 
-			level, err := m.ups.BatteryLevel()
-			if err != nil {
-				shared.Logger.Error(err)
-				continue
-			}
+	var readings = atomic.SwapInt64(&m.readings, 0)
 
-			if level == 0 {
-				continue
-			} // Fuel gauge chip is not yet ready.
+	energy, period, ok := m.energy.Take(time.Now())
+	if !ok {
+		return
+	}
+
+	status.PowerDraw = energy / period.Seconds()
+
+	if readings > 0 {
+		status.EnergyPerReading = energy / float64(readings)
+	}
+}
+
+// detectDepletion determines whether the battery is depleted, so that device must be shut down before power is lost.
+// Depletion must be confirmed by several consecutive readings, since voltage drops shortly under load spikes.
+func (m *PowerManager) detectDepletion(status model.BatteryStatus) bool {
+	var cfg = m.config.Shutdown
+
+	if status.PluggedIn {
+		m.depleted = 0
+		return false
+	}
+
+	// Zero level is also reported while fuel gauge chip is not yet ready, so it is only trusted along with voltage:
+	if (status.Level > 0 && status.Level <= cfg.Level) || (cfg.Voltage > 0 && status.Voltage > 0 && status.Voltage <= cfg.Voltage) {
+		m.depleted++
+	} else {
+		m.depleted = 0
+	}
+
+	return m.depleted > 0 && m.depleted >= cfg.Confirmations
+}
+
+// requestShutdown notifies device about battery depletion, so that it could be shut down gracefully.
+func (m *PowerManager) requestShutdown(ctx context.Context, status model.BatteryStatus) {
+	shared.Logger.Warningf("Battery is depleted (%d%% left, %.0f mV), shutting down device", status.Level, status.Voltage)
+
+	if err := m.SetBattery(status.DeviceBattery); err != nil {
+		shared.Logger.Error(err)
+	}
+
+	eventdriver.EmitEvent(ctx, events.DeviceShutdownRequested, events.DeviceShutdownRequestedPayload{
+		Reason:  "Battery depleted\nshutting down",
+		Command: m.config.Shutdown.Command,
+	})
+}
+
+// applyPowerPolicy selects power profile for the battery `status` and notifies other modules once it changes.
+func (m *PowerManager) applyPowerPolicy(ctx context.Context, status model.BatteryStatus) {
+	profile := m.selectProfile(status)
+	if profile == m.profile {
+		return
+	}
+
+	var settings = m.profileSettings(profile)
 
-			plugged := m.ups.IsPlugged()
+	shared.Logger.Infof("Switching from '%s' to '%s' power profile", m.profile, profile)
 
-			if err = m.SetBattery(models.DeviceBattery{
-				Level: level,
-				PluggedIn: plugged,
-			}); err != nil {
-				shared.Logger.Error(err)
+	m.profile = profile
+
+	localnet.SetAvailable(settings.Bluetooth)
+
+	eventdriver.EmitEvent(ctx, events.PowerProfileChanged, events.PowerProfileChangedPayload{
+		Profile:  profile,
+		Settings: settings,
+	})
+}
+
+// selectProfile determines power profile based on power source and battery level.
+func (m *PowerManager) selectProfile(status model.BatteryStatus) model.PowerProfile {
+	if status.PluggedIn {
+		return model.PowerMains
+	}
+
+	var (
+		critical = m.config.CriticalLevel
+		low      = m.config.LowBatteryLevel
+	)
+
+	switch m.profile {
+	case model.PowerCritical:
+		critical += profileHysteresis
+		low += profileHysteresis
+	case model.PowerLowBattery:
+		low += profileHysteresis
+	}
+
+	switch {
+	case status.Level <= critical:
+		return model.PowerCritical
+	case status.Level <= low:
+		return model.PowerLowBattery
+	default:
+		return model.PowerBattery
+	}
+}
+
+// profileSettings returns settings of the power `profile`, where omitted options are taken from the base configuration.
+func (m *PowerManager) profileSettings(profile model.PowerProfile) config.PowerProfileConfig {
+	var settings config.PowerProfileConfig
+
+	switch profile {
+	case model.PowerMains:
+		settings = m.config.Profiles.Mains
+	case model.PowerBattery:
+		settings = m.config.Profiles.Battery
+	case model.PowerLowBattery:
+		settings = m.config.Profiles.LowBattery
+	case model.PowerCritical:
+		settings = m.config.Profiles.Critical
+	}
+
+	if settings.HotswapInterval == 0 {
+		settings.HotswapInterval = viper.GetDuration("device.hotswap_detect_interval")
+	}
+
+	if settings.GUIUpdateInterval == 0 {
+		settings.GUIUpdateInterval = viper.GetDuration("device.gui_update_interval")
+	}
+
+	if settings.SensorStandbyTimeout == 0 {
+		settings.SensorStandbyTimeout = viper.GetDuration("engine.sensor_sleep_standby_timeout")
+	}
+
+	if settings.SamplingFactor < 1 {
+		settings.SamplingFactor = 1
+	}
+
+	return settings
+}
+
+// listenAlert starts routine waiting for low battery alert signaled via fuel gauge ALRT pin.
+// Returned channel never receives if the pin isn't connected.
+func (m *PowerManager) listenAlert(ctx context.Context) <-chan struct{} {
+	var alertCh = make(chan struct{}, 1)
+
+	if !m.ups.AlertPinConnected() {
+		return alertCh
+	}
+
+	device.Go(ctx, func() {
+		for ctx.Err() == nil {
+			// Timeout allows to periodically check whether the context is done:
+			if !m.ups.WaitForAlert(time.Second) {
+				continue
 			}
 
-			shared.Logger.Debugf("Device battery: %d%% left", level)
+			select {
+			case alertCh <- struct{}{}:
+			default:
+			}
 		}
 	})
+
+	return alertCh
 }
@@ -0,0 +1,20 @@
+package modules
+
+import (
+	"github.com/timoth-y/chainmetric-iot/controllers/device"
+)
+
+// init registers built-in logical modules, so that they could be constructed with device.ModulesFromConfig.
+func init() {
+	device.RegisterModule("LIFECYCLE_MANAGER", WithLifecycleManager)
+	device.RegisterModule("ENGINE_OPERATOR", WithEngineOperator)
+	device.RegisterModule("CACHE_MANAGER", WithCacheManager)
+	device.RegisterModule("EVENTS_OBSERVER", WithEventsObserver)
+	device.RegisterModule("HOTSWAP_DETECTOR", WithHotswapDetector)
+	device.RegisterModule("REMOTE_CONTROLLER", WithRemoteController)
+	device.RegisterModule("LOCATION_MANAGER", WithLocationManager)
+	device.RegisterModule("POWER_MANAGER", WithPowerManager)
+	device.RegisterModule("FAILOVER_HANDLER", WithFailoverHandler)
+	device.RegisterModule("MOTION_MONITOR", WithMotionMonitor)
+	device.RegisterModule("GUI_RENDERER", WithGUIRenderer)
+}
@@ -0,0 +1,208 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/chainmetric-core/models"
+
+	"github.com/timoth-y/chainmetric-iot/controllers/device"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// CommandHandler defines handler of the remote device command executed by RemoteController.
+type CommandHandler struct {
+	// Args declares positional arguments of the command, which are validated before it is handled.
+	Args []CommandArg
+	// Timeout limits duration of the command execution, `remote.command_timeout` is used when not specified.
+	Timeout time.Duration
+	// Handle executes the command and returns its structured output, which is submitted along with results.
+	Handle func(ctx context.Context, cmd *Command) (interface{}, error)
+}
+
+// CommandArgKind defines kind of the remote command argument.
+type CommandArgKind string
+
+const (
+	// ArgString defines kind of the string command argument.
+	ArgString CommandArgKind = "string"
+	// ArgNumber defines kind of the numeric command argument.
+	ArgNumber CommandArgKind = "number"
+	// ArgBool defines kind of the boolean command argument.
+	ArgBool CommandArgKind = "bool"
+)
+
+// CommandArg declares positional argument of the remote command.
+type CommandArg struct {
+	Name     string
+	Kind     CommandArgKind
+	Optional bool
+}
+
+// Command defines remote command being executed on the device.Device.
+type Command struct {
+	*device.Device
+
+	ID   string
+	Name models.DeviceCommand
+
+	args      map[string]interface{}
+	progress  func(percent int, stage string)
+	completed []func()
+}
+
+var (
+	commandHandlers     = make(map[models.DeviceCommand]CommandHandler)
+	commandHandlersLock = &sync.Mutex{}
+)
+
+// RegisterCommand registers `handler` of the remote command `cmd`, so that it would be executed by RemoteController.
+// It is intended to be called from the package `init()` function, including ones of third-party modules.
+// If RegisterCommand is called twice for the same `cmd`, it panics.
+func RegisterCommand(cmd models.DeviceCommand, handler CommandHandler) {
+	commandHandlersLock.Lock()
+	defer commandHandlersLock.Unlock()
+
+	if handler.Handle == nil {
+		panic(fmt.Sprintf("modules: handler of command '%s' is nil", cmd))
+	}
+
+	if _, dup := commandHandlers[cmd]; dup {
+		panic(fmt.Sprintf("modules: command '%s' is registered twice", cmd))
+	}
+
+	commandHandlers[cmd] = handler
+}
+
+// lookupCommand returns handler registered for the remote command `cmd`.
+func lookupCommand(cmd models.DeviceCommand) (CommandHandler, bool) {
+	commandHandlersLock.Lock()
+	defer commandHandlersLock.Unlock()
+
+	handler, ok := commandHandlers[cmd]
+
+	return handler, ok
+}
+
+// bindArgs validates given positional `args` against declared ones and binds them by names.
+func (h CommandHandler) bindArgs(args []interface{}) (map[string]interface{}, error) {
+	var bound = make(map[string]interface{}, len(args))
+
+	if len(args) > len(h.Args) {
+		return nil, errors.Errorf("command takes at most %d arguments, but %d given", len(h.Args), len(args))
+	}
+
+	for i, arg := range h.Args {
+		if i >= len(args) || args[i] == nil {
+			if !arg.Optional {
+				return nil, errors.Errorf("argument '%s' is required", arg.Name)
+			}
+
+			continue
+		}
+
+		var valid bool
+
+		switch arg.Kind {
+		case ArgString:
+			_, valid = args[i].(string)
+		case ArgNumber:
+			_, valid = args[i].(float64)
+		case ArgBool:
+			_, valid = args[i].(bool)
+		}
+
+		if !valid {
+			return nil, errors.Errorf("argument '%s' must be %s, got %T", arg.Name, arg.Kind, args[i])
+		}
+
+		bound[arg.Name] = args[i]
+	}
+
+	return bound, nil
+}
+
+// run executes the command `cmd` with handler under panic recovery and returns its outcome.
+// Handler is expected to return once `ctx` is expired, so it is awaited after the deadline as well,
+// rather than being left running while the command is reported as failed.
+func (h CommandHandler) run(ctx context.Context, cmd *Command) (interface{}, error) {
+	type result struct {
+		output interface{}
+		err    error
+	}
+
+	var done = make(chan result, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				shared.Logger.Debugf("Command '%s' panic stack trace:\n%s", cmd.Name, debug.Stack())
+				done <- result{err: errors.Errorf("panic: %v", r)}
+			}
+		}()
+
+		output, err := h.Handle(ctx, cmd)
+		done <- result{output: output, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.output, res.err
+	case <-ctx.Done():
+		shared.Logger.Warningf("Command '%s' exceeded its deadline, awaiting it to be interrupted", cmd.Name)
+	}
+
+	res := <-done
+
+	if res.err == nil {
+		shared.Logger.Warningf("Command '%s' completed after its deadline", cmd.Name)
+	} else if errors.Cause(res.err) == ctx.Err() {
+		res.err = errors.Wrap(res.err, "command execution interrupted")
+	}
+
+	return res.output, res.err
+}
+
+// Has determines whether the argument by given `name` was passed to the command.
+func (c *Command) Has(name string) bool {
+	_, ok := c.args[name]
+	return ok
+}
+
+// String returns value of the string argument by given `name`, or empty string if it wasn't passed.
+func (c *Command) String(name string) string {
+	v, _ := c.args[name].(string)
+	return v
+}
+
+// Number returns value of the numeric argument by given `name`, or zero if it wasn't passed.
+func (c *Command) Number(name string) float64 {
+	v, _ := c.args[name].(float64)
+	return v
+}
+
+// Bool returns value of the boolean argument by given `name`, or `fallback` if it wasn't passed.
+func (c *Command) Bool(name string, fallback bool) bool {
+	if v, ok := c.args[name].(bool); ok {
+		return v
+	}
+
+	return fallback
+}
+
+// Progress reports execution progress of the command in percents along with description of its current `stage`.
+func (c *Command) Progress(percent int, stage string) {
+	if c.progress != nil {
+		c.progress(percent, stage)
+	}
+}
+
+// OnCompleted defers `fn` until results of the successfully completed command are submitted,
+// e.g. for the actions interrupting device operation, such as reboot.
+func (c *Command) OnCompleted(fn func()) {
+	c.completed = append(c.completed, fn)
+}
@@ -2,13 +2,22 @@ package modules
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-core/models/requests"
 	"github.com/timoth-y/chainmetric-core/utils"
 	"github.com/timoth-y/chainmetric-iot/controllers/device"
+	"github.com/timoth-y/chainmetric-iot/controllers/storage"
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/model"
 	"github.com/timoth-y/chainmetric-iot/model/events"
 	"github.com/timoth-y/chainmetric-iot/network/blockchain"
 	"github.com/timoth-y/chainmetric-iot/network/localnet"
@@ -17,6 +26,7 @@ import (
 )
 
 // RemoteController implements device.Module for device.Device remote commands handling.
+// Commands are executed by handlers registered with RegisterCommand.
 type RemoteController struct {
 	moduleBase
 }
@@ -24,52 +34,656 @@ type RemoteController struct {
 // WithRemoteController can be used to setup RemoteController logical device.Module onto the device.Device.
 func WithRemoteController() device.Module {
 	return &RemoteController{
-		moduleBase: withModuleBase("REMOTE_CONTROLLER"),
+		moduleBase: withModuleBase("REMOTE_CONTROLLER", requires(device.LoggedOnNetwork)),
 	}
 }
 
-func (m *RemoteController) Start(ctx context.Context) {
-	go m.Do(func() {
-		if !m.trySyncWithDeviceLifecycle(ctx, m.Start) {
+// init registers handlers of the built-in remote commands.
+func init() {
+	RegisterCommand(models.DevicePauseCmd, CommandHandler{
+		Handle: handlePauseCmd,
+	})
+	RegisterCommand(models.DeviceResumeCmd, CommandHandler{
+		Handle: handleResumeCmd,
+	})
+	RegisterCommand(models.DevicePairingCmd, CommandHandler{
+		Timeout: 5 * time.Minute,
+		Handle: handleBluetoothPairingCmd,
+	})
+	RegisterCommand(model.DeviceCalibrateCmd, CommandHandler{
+		Timeout: 10 * time.Minute,
+		Handle: handleCalibrateCmd,
+	})
+	RegisterCommand(model.DeviceCalibrateCO2Cmd, CommandHandler{
+		Args: []CommandArg{
+			{Name: "reference_ppm", Kind: ArgNumber},
+			{Name: "duration", Kind: ArgString, Optional: true},
+		},
+		Timeout: co2RecalibrationMaxDuration + 5 * time.Minute,
+		Handle: handleCalibrateCO2Cmd,
+	})
+	RegisterCommand(model.DeviceRebootCmd, CommandHandler{
+		Handle: handleRebootCmd,
+	})
+	RegisterCommand(model.DeviceRescanSensorsCmd, CommandHandler{
+		Handle: handleRescanSensorsCmd,
+	})
+	RegisterCommand(model.DeviceResetIdentityCmd, CommandHandler{
+		Args: []CommandArg{
+			{Name: "reboot", Kind: ArgBool, Optional: true},
+		},
+		Handle: handleResetIdentityCmd,
+	})
+	RegisterCommand(model.DeviceFlushCacheCmd, CommandHandler{
+		Handle: handleFlushCacheCmd,
+	})
+	RegisterCommand(model.DeviceRepostCacheCmd, CommandHandler{
+		Timeout: 5 * time.Minute,
+		Handle: handleRepostCacheCmd,
+	})
+	RegisterCommand(model.DeviceReadNowCmd, CommandHandler{
+		Args: []CommandArg{
+			{Name: "asset_id", Kind: ArgString},
+			{Name: "metrics", Kind: ArgString, Optional: true},
+		},
+		Timeout: 30 * time.Second,
+		Handle: handleReadNowCmd,
+	})
+	RegisterCommand(model.DeviceSelfTestCmd, CommandHandler{
+		Args: []CommandArg{
+			{Name: "sensor_id", Kind: ArgString, Optional: true},
+		},
+		Timeout: 5 * time.Minute,
+		Handle: handleSelfTestCmd,
+	})
+	RegisterCommand(model.DeviceDiagnosticsCmd, CommandHandler{
+		Handle: handleDiagnosticsCmd,
+	})
+	RegisterCommand(model.DeviceUpdateConfigCmd, CommandHandler{
+		Args: []CommandArg{
+			{Name: "version", Kind: ArgNumber},
+			{Name: "patch", Kind: ArgString},
+		},
+		Handle: handleUpdateConfigCmd,
+	})
+}
+
+func (m *RemoteController) Start(ctx context.Context) error {
+	if err := blockchain.Contracts.Devices.ListenCommands(ctx, m.ID(),
+		func(id string, cmd models.DeviceCommand, args ...interface{}) error {
+			m.execute(ctx, id, cmd, args...)
+			return nil
+		},
+	); err != nil {
+		return errors.Wrap(err, "failed to subscribe to device remote commands")
+	}
+
+	return nil
+}
+
+// execute validates arguments of the remote command `name` issued with given `id`,
+// executes it with registered CommandHandler, and submits execution results along with command output.
+func (m *RemoteController) execute(ctx context.Context, id string, name models.DeviceCommand, args ...interface{}) {
+	var (
+		cmd = &Command{
+			Device: m.Device,
+			ID: id,
+			Name: name,
+		}
+		handler, ok = lookupCommand(name)
+		err error
+	)
+
+	if !ok {
+		shared.Logger.Error(errors.Errorf("command '%s' is not supported", name))
+		m.submitResults(id, commandResults(nil, errors.Errorf("command '%s' is not supported", name)))
+		return
+	}
+
+	if cmd.args, err = handler.bindArgs(args); err != nil {
+		shared.Logger.Error(errors.Wrapf(err, "invalid arguments of '%s' command", name))
+		m.submitResults(id, commandResults(nil, errors.Wrap(err, "invalid arguments")))
+		return
+	}
+
+	var timeout = handler.Timeout
+	if timeout <= 0 {
+		timeout = viper.GetDuration("remote.command_timeout")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd.progress = func(percent int, stage string) {
+		if ctx.Err() != nil {
 			return
+		} // Command is past its deadline, so only its final results are submitted.
+
+		var results = commandResults(nil, nil)
+
+		results.Status = models.DeviceCmdProcessing
+		results.Progress = percent
+		results.Stage = stage
+
+		m.submitResults(id, results)
+	}
+
+	shared.Logger.Infof("Executing remote command '%s' with id %s", name, id)
+
+	output, err := handler.run(ctx, cmd)
+	if err != nil {
+		shared.Logger.Error(errors.Wrapf(err, "failed to execute '%s' command", name))
+	}
+
+	m.submitResults(id, commandResults(output, err))
+
+	if err == nil {
+		for _, fn := range cmd.completed {
+			fn()
+		}
+	}
+}
+
+func (m *RemoteController) submitResults(id string, results model.DeviceCommandResults) {
+	results.Timestamp = time.Now().UTC()
+
+	if err := blockchain.Contracts.Devices.SubmitCommandOutput(id, results); err != nil {
+		shared.Logger.Error(err)
+	}
+}
+
+// commandResults forms results of the command execution with given `output`,
+// which is considered failed if `err` is specified.
+func commandResults(output interface{}, err error) model.DeviceCommandResults {
+	var results = model.DeviceCommandResults{
+		DeviceCommandResultsSubmitRequest: requests.DeviceCommandResultsSubmitRequest{
+			Status: models.DeviceCmdCompleted,
+		},
+		Progress: 100,
+		Output: output,
+	}
+
+	if err != nil {
+		results.Status = models.DeviceCmdFailed
+		results.Error = utils.StringPointer(err.Error())
+		results.Progress = 0
+	}
+
+	return results
+}
+
+func handlePauseCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	return nil, cmd.Pause(ctx)
+}
+
+func handleResumeCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	return nil, cmd.Resume(ctx)
+}
+
+func handleCalibrateCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	var (
+		duration = viper.GetDuration("sensors.calibration_duration")
+		calibrators = cmd.RegisteredSensors().Calibrators()
+		calibrated = make(map[string]bool)
+		failure error
+	)
+
+	if len(calibrators) == 0 {
+		return nil, errors.New("there are no sensors requiring calibration")
+	}
+
+	eventdriver.EmitEvent(ctx, events.SensorsCalibrationStarted, duration)
+
+	for i, sn := range calibrators {
+		if ctx.Err() != nil {
+			return calibrated, errors.Wrap(ctx.Err(), "calibration is interrupted")
+		}
+
+		cmd.Progress(i * 100 / len(calibrators), fmt.Sprintf("calibrating %s sensor", sn.ID()))
+		calibrated[sn.ID()] = false
+
+		if err := sensor.Acquire(sn); err != nil {
+			failure = errors.Wrapf(err, "failed to initialize %s sensor for calibration", sn.ID())
+			shared.Logger.Error(failure)
+			continue
+		}
+
+		data, err := sn.Calibrate(ctx, duration)
+		sensor.Release(sn)
+
+		if err != nil {
+			failure = errors.Wrapf(err, "failed to calibrate %s sensor", sn.ID())
+			shared.Logger.Error(failure)
+			continue
+		}
+
+		if err = storage.PutCalibration(sn.CalibrationKey(), data); err != nil {
+			failure = errors.Wrapf(err, "failed to persist calibration of %s sensor", sn.ID())
+			shared.Logger.Error(failure)
+			continue
 		}
 
-		if err := blockchain.Contracts.Devices.ListenCommands(ctx, m.ID(),
-			func(id string, cmd models.DeviceCommand, args ...interface{}) error {
-				switch cmd {
-				case models.DevicePauseCmd:
-				case models.DeviceResumeCmd:
-				case models.DevicePairingCmd:
-					m.handleBluetoothPairingCmd(ctx, id)
-				default:
-					shared.Logger.Error(errors.Errorf("command '%s' is not supported", cmd))
+		calibrated[sn.ID()] = true
+		shared.Logger.Infof("Sensor %s was successfully calibrated", sn.ID())
+	}
+
+	return calibrated, failure
+}
+
+// co2RecalibrationMaxDuration limits duration of CO2 sensors measuring the reference environment before recalibration.
+const co2RecalibrationMaxDuration = 30 * time.Minute
+
+// handleCalibrateCO2Cmd performs forced recalibration of the CO2 sensors to the reference concentration given by command,
+// once those were measuring the reference environment for the given or configured duration.
+func handleCalibrateCO2Cmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	var (
+		reference = cmd.Number("reference_ppm")
+		duration = viper.GetDuration("sensors.ndir.recalibration_duration")
+		recalibrators = cmd.RegisteredSensors().CO2Recalibrators()
+		recalibrated = make(map[string]bool)
+		failure error
+	)
+
+	if len(recalibrators) == 0 {
+		return nil, errors.New("there are no CO2 sensors supporting recalibration")
+	}
+
+	if reference < 300 || reference > 5000 {
+		return nil, errors.Errorf("reference CO2 concentration must be from 300 to 5000 ppm, got %v", reference)
+	}
+
+	if cmd.Has("duration") {
+		var err error
+		if duration, err = time.ParseDuration(cmd.String("duration")); err != nil {
+			return nil, errors.Wrap(err, "invalid recalibration duration")
+		}
+	}
+
+	if duration > co2RecalibrationMaxDuration {
+		return nil, errors.Errorf("recalibration duration must not exceed %s", co2RecalibrationMaxDuration)
+	}
+
+	for _, sn := range recalibrators {
+		if duration < sn.RecalibrationWarmUp() {
+			return nil, errors.Errorf("%s sensor must measure reference environment at least %s before recalibration",
+				sn.ID(), sn.RecalibrationWarmUp())
+		}
+	}
+
+	// Sensors are held leased, so that engine won't close them on stand-by while measuring reference environment:
+	for i, sn := range recalibrators {
+		if err := sensor.Acquire(sn); err != nil {
+			for _, leased := range recalibrators[:i] {
+				sensor.Release(leased)
+			}
+
+			return nil, errors.Wrapf(err, "failed to initialize %s sensor for recalibration", sn.ID())
+		}
+	}
+
+	defer func() {
+		for _, sn := range recalibrators {
+			sensor.Release(sn)
+		}
+	}()
+
+	cmd.Progress(0, fmt.Sprintf("measuring reference environment for %s", duration))
+
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	for i, sn := range recalibrators {
+		cmd.Progress(i * 100 / len(recalibrators), fmt.Sprintf("recalibrating %s sensor", sn.ID()))
+		recalibrated[sn.ID()] = false
+
+		if err := sn.Recalibrate(int(reference)); err != nil {
+			failure = errors.Wrapf(err, "failed to recalibrate %s sensor", sn.ID())
+			shared.Logger.Error(failure)
+			continue
+		}
+
+		recalibrated[sn.ID()] = true
+		shared.Logger.Infof("Sensor %s was successfully recalibrated to %v ppm", sn.ID(), reference)
+	}
+
+	return recalibrated, failure
+}
+
+func handleBluetoothPairingCmd(ctx context.Context, _ *Command) (interface{}, error) {
+	eventdriver.EmitEvent(ctx, events.BluetoothPairingStarted, nil)
+
+	if err := localnet.Pair(ctx); err != nil && errors.Cause(err) != context.DeadlineExceeded {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func handleRebootCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	cmd.OnCompleted(func() {
+		requestReboot(ctx)
+	})
+
+	return nil, nil
+}
+
+// requestReboot requests graceful device shutdown followed by `remote.reboot_command` execution.
+func requestReboot(ctx context.Context) {
+	eventdriver.EmitEvent(ctx, events.DeviceShutdownRequested, events.DeviceShutdownRequestedPayload{
+		Reason: "Rebooting...",
+		Command: viper.GetString("remote.reboot_command"),
+	})
+}
+
+func handleRescanSensorsCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	var (
+		payload = detectSensorsChanges(ctx, cmd.Device)
+		output = struct {
+			Added   []string `json:"added,omitempty"`
+			Removed []string `json:"removed,omitempty"`
+			Sensors []string `json:"sensors"`
+		}{
+			Removed: payload.Removed,
+		}
+	)
+
+	for _, sn := range payload.Added {
+		output.Added = append(output.Added, sn.ID())
+	}
+
+	for id := range cmd.RegisteredSensors() {
+		output.Sensors = append(output.Sensors, id)
+	}
+
+	sort.Strings(output.Sensors)
+
+	return output, nil
+}
+
+func handleResetIdentityCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	if err := resetDevice(false); err != nil {
+		return nil, errors.Wrap(err, "failed to reset device identity")
+	}
+
+	// Device is registered anew on startup, so it is rebooted unless explicitly asked not to:
+	if cmd.Bool("reboot", true) {
+		cmd.OnCompleted(func() {
+			requestReboot(ctx)
+		})
+	}
+
+	return nil, nil
+}
+
+func handleFlushCacheCmd(_ context.Context, _ *Command) (interface{}, error) {
+	if shared.LevelDB == nil {
+		return nil, errors.New("failover cache isn't available without LevelDB")
+	}
+
+	flushed, err := storage.FlushCachedReadings()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to flush cached readings")
+	}
+
+	return map[string]int{"flushed": flushed}, nil
+}
+
+func handleRepostCacheCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	if shared.LevelDB == nil {
+		return nil, errors.New("failover cache isn't available without LevelDB")
+	}
+
+	cached, err := storage.CountCachedReadings()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count cached readings")
+	}
+
+	cmd.Progress(0, fmt.Sprintf("posting %d cached readings", cached))
+
+	posted, networkDown := repostCachedReadings(ctx)
+	if networkDown {
+		return map[string]int{"posted": posted}, errors.Errorf(
+			"network connection is down, %d of %d cached readings posted", posted, cached,
+		)
+	}
+
+	if ctx.Err() != nil {
+		return map[string]int{"posted": posted}, errors.Wrapf(ctx.Err(),
+			"reposting is interrupted, %d of %d cached readings posted", posted, cached,
+		)
+	}
+
+	return map[string]int{"posted": posted}, nil
+}
+
+func handleReadNowCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	var (
+		assetID = cmd.String("asset_id")
+		metrics models.Metrics
+		results = make(chan map[models.Metric]float64, 1)
+	)
+
+	if cmd.IsPaused() {
+		return nil, errors.New("readings can't be taken while device is paused")
+	}
+
+	if !cmd.ExistsAssetInCache(assetID) {
+		return nil, errors.Errorf("asset '%s' isn't in range of the device", assetID)
+	}
+
+	if cmd.Has("metrics") {
+		for _, metric := range strings.Split(cmd.String("metrics"), ",") {
+			if metric = strings.TrimSpace(metric); len(metric) != 0 {
+				metrics = append(metrics, models.Metric(metric))
+			}
+		}
+	} else {
+		var required = make(map[models.Metric]bool)
+
+		for _, request := range cmd.GetCachedRequirementsFor(assetID) {
+			for _, metric := range request.Metrics {
+				if !required[metric] {
+					required[metric] = true
+					metrics = append(metrics, metric)
 				}
-				return nil
-			},
-		); err != nil {
-			shared.Logger.Error(errors.Wrap(err, "failed to subscribe to device remote commands"))
+			}
 		}
+	}
+
+	if len(metrics) == 0 {
+		return nil, errors.Errorf("there are no metrics required for asset '%s'", assetID)
+	}
+
+	eventdriver.EmitEvent(ctx, events.ReadingsRequested, events.ReadingsRequestedPayload{
+		AssetID: assetID,
+		Metrics: metrics,
+		Results: results,
 	})
+
+	select {
+	case readings, ok := <-results:
+		if !ok {
+			return nil, errors.New("readings can't be taken since sensors reader engine isn't running")
+		}
+
+		return readings, nil
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "failed to take readings")
+	}
 }
 
-func (m *RemoteController) handleBluetoothPairingCmd(ctx context.Context, cmdID string) {
+func handleSelfTestCmd(ctx context.Context, cmd *Command) (interface{}, error) {
 	var (
-		results = requests.DeviceCommandResultsSubmitRequest{
-			Status: models.DeviceCmdCompleted,
+		sensorID = cmd.String("sensor_id")
+		targets []sensor.Sensor
+		results []model.SensorSelfTest
+	)
+
+	if cmd.IsPaused() {
+		return nil, errors.New("sensors are parked while device is paused")
+	}
+
+	for _, sn := range cmd.RegisteredSensors().ToList() {
+		if len(sensorID) == 0 || sn.ID() == sensorID {
+			targets = append(targets, sn)
 		}
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.New("there are no sensors to test")
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].ID() < targets[j].ID()
+	})
+
+	for i, sn := range targets {
+		if ctx.Err() != nil {
+			return results, errors.Wrap(ctx.Err(), "self-test is interrupted")
+		}
+
+		cmd.Progress(i * 100 / len(targets), fmt.Sprintf("testing %s sensor", sn.ID()))
+		results = append(results, selfTestSensor(ctx, sn))
+	}
+
+	return results, nil
+}
+
+// selfTestSensor checks whether the sensor `sn` passes verification, initializes, and produces readings.
+// Sensors which weren't active before the test are closed afterwards, so that they stay in standby.
+func selfTestSensor(ctx context.Context, sn sensor.Sensor) model.SensorSelfTest {
+	var result = model.SensorSelfTest{
+		SensorID: sn.ID(),
+	}
+
+	if !sn.Verify() {
+		result.Error = "sensor didn't pass verification"
+		return result
+	}
+
+	var active = sn.Active()
+
+	if err := sensor.Acquire(sn); err != nil {
+		result.Error = errors.Wrap(err, "failed to initialize sensor").Error()
+		return result
+	}
+
+	defer func() {
+		sensor.Release(sn)
+
+		if !active {
+			shared.Execute(func() error {
+				_, err := sensor.CloseIdle(sn)
+				return err
+			}, fmt.Sprintf("failed to close connection to '%s' sensor", sn.ID()))
+		}
+	}()
+
+	var (
+		readCtx, cancel = context.WithTimeout(ctx, 3 * time.Second)
+		sensorCtx = sensor.NewReaderContext(readCtx, sn)
+		done = make(chan struct{})
 	)
 
-	eventdriver.EmitEvent(ctx, events.BluetoothPairingStarted, nil)
+	defer cancel()
 
-	if err := localnet.Pair(ctx); err != nil && errors.Cause(err) != context.DeadlineExceeded {
-		results.Status = models.DeviceCmdFailed
-		results.Error = utils.StringPointer(err.Error())
-		shared.Logger.Error(err)
+	for _, metric := range sn.Metrics() {
+		sensorCtx.Pipe[metric] = make(chan sensor.ReadingResult, 10)
 	}
 
-	results.Timestamp = time.Now().UTC()
+	go func() {
+		sn.Harvest(sensorCtx)
+		close(done)
+	}()
 
-	if err := blockchain.Contracts.Devices.SubmitCommandResults(cmdID, results); err != nil {
-		shared.Logger.Error(err)
+	select {
+	case <-done:
+	case <-readCtx.Done():
+		result.Error = "sensor reading timeout"
+		return result
 	}
+
+	for metric, ch := range sensorCtx.Pipe {
+		if len(ch) != 0 {
+			result.Metrics = append(result.Metrics, metric)
+		}
+	}
+
+	sort.Slice(result.Metrics, func(i, j int) bool {
+		return result.Metrics[i] < result.Metrics[j]
+	})
+
+	if result.Passed = len(result.Metrics) != 0; !result.Passed {
+		result.Error = "sensor produced no readings"
+	}
+
+	return result
+}
+
+func handleDiagnosticsCmd(_ context.Context, cmd *Command) (interface{}, error) {
+	var (
+		memStats runtime.MemStats
+		diagnostics = model.DeviceDiagnostics{
+			Timestamp: time.Now().UTC(),
+			State: cmd.State(),
+			Paused: cmd.IsPaused(),
+			ConfigVersion: shared.ConfigVersion(),
+			ConfigPending: shared.ConfigPatchPending(),
+			Specs: cmd.Specs(),
+			Battery: cmd.Battery(),
+			Modules: cmd.ModulesHealth(),
+			BlockedModules: cmd.BlockedModules(),
+			CachedAssets: len(cmd.GetCachedAssets()),
+			CachedRequests: len(cmd.GetCachedRequirements()),
+			Goroutines: runtime.NumGoroutine(),
+		}
+	)
+
+	runtime.ReadMemStats(&memStats)
+	diagnostics.MemoryAlloc = memStats.Alloc
+
+	for id := range cmd.RegisteredSensors() {
+		diagnostics.Sensors = append(diagnostics.Sensors, id)
+	}
+
+	sort.Strings(diagnostics.Sensors)
+
+	if shared.LevelDB != nil {
+		if count, err := storage.CountCachedReadings(); err == nil {
+			diagnostics.CachedReadings = count
+		} else {
+			shared.Logger.Error(errors.Wrap(err, "failed to count cached readings"))
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// handleUpdateConfigCmd applies configuration patch, rebooting the device if any of the changed keys requires restart.
+func handleUpdateConfigCmd(ctx context.Context, cmd *Command) (interface{}, error) {
+	var version = cmd.Number("version")
+
+	if version != math.Trunc(version) {
+		return nil, errors.Errorf("config version must be integer, got %v", version)
+	}
+
+	live, restart, err := shared.ApplyConfigPatch(int(version), []byte(cmd.String("patch")))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply config patch")
+	}
+
+	if len(restart) != 0 {
+		cmd.OnCompleted(func() {
+			requestReboot(ctx)
+		})
+	}
+
+	return model.ConfigPatchResults{
+		Version: int(version),
+		Applied: live,
+		Restart: restart,
+	}, nil
 }
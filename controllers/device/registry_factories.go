@@ -0,0 +1,116 @@
+package device
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// ModuleFactory defines constructor of the logical Module.
+type ModuleFactory func() Module
+
+var (
+	factories     = make(map[string]ModuleFactory)
+	factoriesMIDs []string
+	factoriesLock = &sync.Mutex{}
+)
+
+// RegisterModule makes logical Module constructed by `factory` available by given `mid`,
+// so that it could be enabled or disabled in `modules` config section.
+// It is intended to be called from the package `init()` function, including ones of third-party modules.
+// If RegisterModule is called twice with the same `mid`, it panics.
+func RegisterModule(mid string, factory ModuleFactory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if factory == nil {
+		panic(fmt.Sprintf("device: factory of module '%s' is nil", mid))
+	}
+
+	if _, dup := factories[mid]; dup {
+		panic(fmt.Sprintf("device: module '%s' is registered twice", mid))
+	}
+
+	factories[mid] = factory
+	factoriesMIDs = append(factoriesMIDs, mid)
+}
+
+// RegisteredModules returns IDs of the modules available for construction in order of their registration.
+func RegisteredModules() []string {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	return append([]string(nil), factoriesMIDs...)
+}
+
+// ModulesFromConfig constructs registered logical modules, which are enabled in `modules` config section.
+// Modules absent in the configuration are enabled by default.
+func ModulesFromConfig() []Module {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	var (
+		modules    []Module
+		registered = make(map[string]bool)
+		unknown    []string
+	)
+
+	for _, mid := range factoriesMIDs {
+		registered[strings.ToLower(mid)] = true
+	}
+
+	// Configured keys are collected in advance, since decoding binds registered ones onto viper:
+	for key := range viper.GetStringMap("modules") {
+		if !registered[key] {
+			unknown = append(unknown, key)
+		}
+	}
+
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		shared.Logger.Warningf("Module '%s' is configured, but no such module is registered", key)
+	}
+
+	for _, mid := range factoriesMIDs {
+		var cfg = config.ModuleConfig{
+			Enabled: true,
+		}
+
+		if err := shared.UnmarshalFromConfig(moduleConfigKey(mid), &cfg); err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to parse config of module '%s', it will be enabled", mid))
+		}
+
+		if !cfg.Enabled {
+			shared.Logger.Infof("Module '%s' is disabled in configuration", mid)
+			continue
+		}
+
+		modules = append(modules, factories[mid]())
+	}
+
+	return modules
+}
+
+// UnmarshalModuleSettings decodes `settings` of the module by given `mid` from `modules` config section
+// into given typed structure `v`. Fields absent in the settings are left intact,
+// so settings can be used to override ones read from module's general config section.
+func UnmarshalModuleSettings(mid string, v interface{}) error {
+	if err := shared.UnmarshalFromConfig(moduleConfigKey(mid)+".settings", v); err != nil {
+		return errors.Wrapf(err, "failed to parse settings of module '%s'", mid)
+	}
+
+	return nil
+}
+
+// moduleConfigKey returns config key of the module by given `mid`.
+// Viper keys are case-insensitive, so module IDs are lowercased.
+func moduleConfigKey(mid string) string {
+	return "modules." + strings.ToLower(mid)
+}
@@ -3,19 +3,49 @@ package device
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/timoth-y/chainmetric-iot/model"
 	"github.com/timoth-y/chainmetric-iot/shared"
 )
 
 // ModulesRegistry defines pool of registered logical Module's extending the Device functionality.
-type ModulesRegistry []Module
+//
+// Modules are set up and started in order of their dependencies,
+// where ones with unmet dependencies or readiness conditions are started once those are met.
+// Started modules are run by Supervisor.
+type ModulesRegistry struct {
+	modules    []Module
+	device     *Device
+	supervisor *Supervisor
+	ctx        context.Context
+	started    map[string]bool
+	pending    []Module
+	mutex      sync.Mutex
+}
+
+func newModulesRegistry(modules ...Module) *ModulesRegistry {
+	return &ModulesRegistry{
+		modules:    modules,
+		supervisor: newSupervisor(),
+		started:    make(map[string]bool),
+	}
+}
 
 // Setup registers all logical device.Module's presented in ModulesRegistry onto the device.Device instance.
-func (r ModulesRegistry) Setup(device *Device) {
+func (r *ModulesRegistry) Setup(device *Device) {
 	shared.Logger.Info("Setting up logical modules for the device...")
 
-	for _, module := range r {
+	r.device = device
+	r.modules = r.sortByDependencies()
+
+	if err := r.supervisor.configure(); err != nil {
+		shared.Logger.Error(errors.Wrap(err, "default modules supervision policy will be used"))
+	}
+
+	for _, module := range r.modules {
 		if err := module.Setup(device); err == nil {
 			shared.Logger.Info(
 				"\033[32m[✔]\033[0m",
@@ -31,27 +61,170 @@ func (r ModulesRegistry) Setup(device *Device) {
 }
 
 // Start starts all presented in ModulesRegistry logical device.Module's operational routine.
-func (r ModulesRegistry) Start(ctx context.Context) {
+// Modules awaiting for dependencies or readiness conditions are postponed until those are met.
+func (r *ModulesRegistry) Start(ctx context.Context) {
 	shared.Logger.Info("Device startup sequence started...")
 
-	for _, m := range r {
+	r.mutex.Lock()
+	r.ctx = ctx
+	for _, m := range r.modules {
 		if m.IsReady() {
-			m.Start(ctx)
-			shared.Logger.Infof("\u001B[32m[⬤]\u001B[0m Module '%s' stated", m.MID())
-
+			r.pending = append(r.pending, m)
+			r.supervisor.Track(m, model.ModulePending)
 			continue
 		}
 
+		r.supervisor.Track(m, model.ModuleDisabled)
 		shared.Logger.Warningf("\033[33m[🡆]\u001B[0m Module '%s' started is skipped due not readiness", m.MID())
 	}
+	r.mutex.Unlock()
+
+	r.startPending()
+
+	for mid, reasons := range r.Blocked() {
+		shared.Logger.Warningf("\033[33m[🡆]\u001B[0m Module '%s' is awaiting for %s", mid, strings.Join(reasons, ", "))
+	}
 
 	shared.Logger.Info("Device is ready and running")
 }
 
-func (r ModulesRegistry) Close() {
+// startPending starts pending modules, which dependencies and readiness conditions are met by now.
+// Since started module can unblock other ones, it repeats until there is nothing left to start.
+func (r *ModulesRegistry) startPending() {
+	for {
+		var ready []Module
+
+		r.mutex.Lock()
+		if r.ctx == nil {
+			r.mutex.Unlock()
+			return
+		} // Startup sequence isn't started yet.
+
+		var pending []Module
+		for _, m := range r.pending {
+			if len(r.unmetRequirements(m)) == 0 {
+				r.started[m.MID()] = true
+				ready = append(ready, m)
+				continue
+			}
+
+			pending = append(pending, m)
+		}
+
+		r.pending = pending
+		ctx := r.ctx
+		r.mutex.Unlock()
+
+		if len(ready) == 0 {
+			return
+		}
+
+		for _, m := range ready {
+			r.supervisor.Run(ctx, m)
+			shared.Logger.Infof("\u001B[32m[⬤]\u001B[0m Module '%s' stated", m.MID())
+		}
+	}
+}
+
+// Blocked returns modules awaiting for unmet dependencies or readiness conditions along with descriptions of those.
+func (r *ModulesRegistry) Blocked() map[string][]string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var blocked = make(map[string][]string)
+
+	for _, m := range r.pending {
+		blocked[m.MID()] = r.unmetRequirements(m)
+	}
+
+	return blocked
+}
+
+// unmetRequirements describes dependencies and readiness conditions of the module `m`, which aren't met yet.
+// Must be called under the registry lock.
+func (r *ModulesRegistry) unmetRequirements(m Module) []string {
+	var unmet []string
+
+	for _, dep := range m.Dependencies() {
+		if !r.started[dep] {
+			unmet = append(unmet, fmt.Sprintf("module '%s'", dep))
+		}
+	}
+
+	for _, cond := range m.Conditions() {
+		if !r.device.ConditionMet(cond) {
+			unmet = append(unmet, fmt.Sprintf("condition '%s'", cond))
+		}
+	}
+
+	return unmet
+}
+
+// sortByDependencies orders modules so that each one goes after its dependencies, preserving registration order otherwise.
+// Modules with unregistered or cyclic dependencies are kept at the end, they won't be started anyway.
+func (r *ModulesRegistry) sortByDependencies() []Module {
+	var (
+		sorted   = make([]Module, 0, len(r.modules))
+		placed   = make(map[string]bool)
+		known    = make(map[string]bool)
+		progress = true
+	)
+
+	for _, m := range r.modules {
+		known[m.MID()] = true
+	}
+
+	for progress {
+		progress = false
+
+	NEXT:
+		for _, m := range r.modules {
+			if placed[m.MID()] {
+				continue
+			}
+
+			for _, dep := range m.Dependencies() {
+				if !placed[dep] {
+					continue NEXT
+				}
+			}
+
+			sorted = append(sorted, m)
+			placed[m.MID()] = true
+			progress = true
+		}
+	}
+
+	for _, m := range r.modules {
+		if placed[m.MID()] {
+			continue
+		}
+
+		for _, dep := range m.Dependencies() {
+			if !known[dep] {
+				shared.Logger.Errorf("Module '%s' depends on module '%s', which isn't registered or enabled", m.MID(), dep)
+			} else if !placed[dep] {
+				shared.Logger.Errorf("Module '%s' has unresolvable dependency on module '%s'", m.MID(), dep)
+			}
+		}
+
+		sorted = append(sorted, m)
+	}
+
+	return sorted
+}
+
+// Close stops all ready logical device.Module's in reverse order, so that modules are closed before their dependencies.
+func (r *ModulesRegistry) Close() {
 	shared.Logger.Info("Device shutdown sequence started...")
 
-	for _, m := range r {
+	r.mutex.Lock()
+	r.pending = nil
+	r.mutex.Unlock()
+
+	for i := len(r.modules) - 1; i >= 0; i-- {
+		m := r.modules[i]
+
 		if !m.IsReady() {
 			continue
 		}
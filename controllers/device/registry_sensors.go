@@ -3,6 +3,7 @@ package device
 import (
 	"github.com/pkg/errors"
 	"github.com/timoth-y/chainmetric-core/models/requests"
+	"github.com/timoth-y/chainmetric-iot/controllers/storage"
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
 	"github.com/timoth-y/chainmetric-iot/network/blockchain"
 	"github.com/timoth-y/chainmetric-iot/shared"
@@ -15,6 +16,8 @@ func (d *Device) RegisteredSensors() sensor.SensorsRegister {
 
 // RegisterSensors adds given `sensors` on the Device sensors pool.
 func (d *Device) RegisterSensors(sensors ...sensor.Sensor) {
+	restoreCalibrations(sensors...)
+
 	for i, s := range sensors {
 		d.sensors[s.ID()] = sensors[i]
 	}
@@ -22,6 +25,8 @@ func (d *Device) RegisterSensors(sensors ...sensor.Sensor) {
 	if d.IsLoggedToNetwork() {
 		d.updateSupportedMetrics()
 	}
+
+	d.signalReadiness()
 }
 
 // UnregisterSensor removes sensor by given `id` from the Device sensors pool.
@@ -37,6 +42,8 @@ func (d *Device) UnregisterSensor(id string) {
 
 // UpdateSensorsRegister applies changes in sensor.SensorsRegister of the Device.
 func (d *Device) UpdateSensorsRegister(added []sensor.Sensor, removed []string) {
+	restoreCalibrations(added...)
+
 	for i, s := range added {
 		d.sensors[s.ID()] = added[i]
 	}
@@ -48,6 +55,32 @@ func (d *Device) UpdateSensorsRegister(added []sensor.Sensor, removed []string)
 	if d.IsLoggedToNetwork() {
 		d.updateSupportedMetrics()
 	}
+
+	d.signalReadiness()
+}
+
+// restoreCalibrations applies calibration persisted for the sensor.Calibrator instances among given `sensors`.
+func restoreCalibrations(sensors ...sensor.Sensor) {
+	for _, sn := range sensors {
+		calibrator, ok := sn.(sensor.Calibrator)
+		if !ok {
+			continue
+		}
+
+		data, found, err := storage.GetCalibration(calibrator.CalibrationKey())
+		if err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to load calibration for %s sensor", sn.ID()))
+			continue
+		}
+
+		if !found {
+			continue
+		}
+
+		if err = calibrator.RestoreCalibration(data); err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to restore calibration for %s sensor", sn.ID()))
+		}
+	}
 }
 
 func (d *Device) updateSupportedMetrics() {
@@ -0,0 +1,70 @@
+package device
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"periph.io/x/periph/conn/i2c/i2ctest"
+
+	"github.com/timoth-y/chainmetric-iot/controllers/storage"
+	"github.com/timoth-y/chainmetric-iot/core/compass"
+	"github.com/timoth-y/chainmetric-iot/drivers/sensors"
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// restoreSpy records calibration restored into the wrapped magnetometer.
+type restoreSpy struct {
+	*sensors.LSM303Magnetometer
+	restored []byte
+}
+
+func (s *restoreSpy) RestoreCalibration(data []byte) error {
+	s.restored = data
+	return s.LSM303Magnetometer.RestoreCalibration(data)
+}
+
+func TestRestoreCalibrationsOnFreshSensor(t *testing.T) {
+	db, err := leveldb.OpenFile(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared.LevelDB = db
+	defer func() {
+		shared.LevelDB = nil
+		_ = db.Close()
+	}()
+
+	var (
+		calibrated = sensors.NewMagnetometerLSM303(sensors.LSM303C_M_ADDRESS, 1).(*sensors.LSM303Magnetometer)
+		fresh      = &restoreSpy{
+			LSM303Magnetometer: sensors.NewMagnetometerLSM303(sensors.LSM303C_M_ADDRESS, 1).(*sensors.LSM303Magnetometer),
+		}
+		calibration = compass.Calibration{
+			Offset:   model.Vector{X: 12.5, Y: -3, Z: 0.75},
+			SoftIron: compass.Identity().SoftIron,
+		}
+	)
+
+	// Calibration is persisted by `calibrate` command once sensor is initialized, which opens its bus:
+	calibrated.I2C.Bus = &i2ctest.Record{}
+
+	data, err := json.Marshal(calibration)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = storage.PutCalibration(calibrated.CalibrationKey(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	// While it is restored on freshly detected sensors, which aren't initialized yet:
+	restoreCalibrations(fresh)
+
+	if !bytes.Equal(fresh.restored, data) {
+		t.Fatalf("calibration wasn't restored: got %q, want %q", fresh.restored, data)
+	}
+}
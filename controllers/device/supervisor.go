@@ -0,0 +1,300 @@
+package device
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/go-eventdriver"
+
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/model/events"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// Supervisor runs Module's operational routines under panic recovery
+// and restarts them once those end according to the RestartPolicy configured for each Module.
+type Supervisor struct {
+	config config.SupervisorConfig
+	mutex  sync.Mutex
+	order  []string
+	health map[string]*model.ModuleHealth
+}
+
+// moduleRun defines state of the single Module's routine run, which is passed along with its context.
+type moduleRun struct {
+	mutex  sync.Mutex
+	err    error
+	cancel context.CancelFunc
+}
+
+type moduleRunKey struct{}
+
+func newSupervisor() *Supervisor {
+	return &Supervisor{
+		config: config.SupervisorConfig{
+			RestartPolicy: string(model.RestartOnFailure),
+			Backoff:       time.Second,
+			MaxBackoff:    time.Minute,
+		},
+		health: make(map[string]*model.ModuleHealth),
+	}
+}
+
+// configure reads supervision configuration, default one is kept on failure.
+func (s *Supervisor) configure() error {
+	var cfg config.SupervisorConfig
+
+	if err := shared.UnmarshalFromConfig("supervisor", &cfg); err != nil {
+		return errors.Wrap(err, "failed to parse supervisor config")
+	}
+
+	if cfg.Backoff <= 0 || cfg.MaxBackoff < cfg.Backoff {
+		return errors.New("supervisor backoff must be positive and not exceed max backoff")
+	}
+
+	// Mistyped policy would otherwise silently mean that module is never restarted:
+	if !model.RestartPolicy(cfg.RestartPolicy).Valid() {
+		shared.Logger.Warningf("Unknown supervisor restart policy '%s', '%s' is used instead",
+			cfg.RestartPolicy, model.RestartOnFailure)
+		cfg.RestartPolicy = string(model.RestartOnFailure)
+	}
+
+	for mid, policy := range cfg.Modules {
+		if !model.RestartPolicy(policy).Valid() {
+			shared.Logger.Warningf("Unknown restart policy '%s' of module '%s', '%s' is used instead",
+				policy, mid, model.RestartOnFailure)
+			cfg.Modules[mid] = string(model.RestartOnFailure)
+		}
+	}
+
+	s.config = cfg
+
+	return nil
+}
+
+// Track starts tracking health of the module `m` with given initial `status`.
+func (s *Supervisor) Track(m Module, status model.ModuleStatus) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.health[m.MID()]; !ok {
+		s.order = append(s.order, m.MID())
+	}
+
+	s.health[m.MID()] = &model.ModuleHealth{
+		MID:    m.MID(),
+		Status: status,
+		Policy: s.policy(m.MID()),
+	}
+}
+
+// Run starts module `m` routine in the background under panic recovery,
+// restarting it with exponential backoff once it ends according to module's RestartPolicy.
+func (s *Supervisor) Run(ctx context.Context, m Module) {
+	go func() {
+		var (
+			policy  = s.policy(m.MID())
+			backoff = s.config.Backoff
+		)
+
+		for {
+			startedAt := time.Now()
+			s.update(ctx, m.MID(), func(h *model.ModuleHealth) {
+				h.Status = model.ModuleRunning
+				h.StartedAt = startedAt
+			})
+
+			err := s.runOnce(ctx, m)
+
+			if ctx.Err() != nil {
+				s.update(ctx, m.MID(), func(h *model.ModuleHealth) {
+					h.Status = model.ModuleStopped
+				})
+
+				return
+			} // Device is shutting down, so there is nothing to restart.
+
+			if err != nil {
+				shared.Logger.Errorf("Module '%s' failed: %s", m.MID(), err)
+			}
+
+			if !s.shouldRestart(m.MID(), policy, err) {
+				s.update(ctx, m.MID(), func(h *model.ModuleHealth) {
+					if err != nil {
+						h.Status = model.ModuleFailed
+						h.LastError = err.Error()
+					} else {
+						h.Status = model.ModuleStopped
+					}
+				})
+
+				return
+			}
+
+			// Module which was running for a while is considered recovered, so backoff starts over:
+			if time.Since(startedAt) > s.config.MaxBackoff {
+				backoff = s.config.Backoff
+			}
+
+			s.update(ctx, m.MID(), func(h *model.ModuleHealth) {
+				h.Status = model.ModuleRestarting
+				h.Restarts++
+				if err != nil {
+					h.LastError = err.Error()
+				}
+			})
+
+			shared.Logger.Warningf("Module '%s' will be restarted in %s", m.MID(), backoff)
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				s.update(ctx, m.MID(), func(h *model.ModuleHealth) {
+					h.Status = model.ModuleStopped
+				})
+
+				return
+			}
+
+			if backoff *= 2; backoff > s.config.MaxBackoff {
+				backoff = s.config.MaxBackoff
+			}
+		}
+	}()
+}
+
+// runOnce runs module `m` routine until it ends, recovering from panic if such occurs.
+// Context passed to the routine is canceled once it ends, so that resources bound to it are released.
+func (s *Supervisor) runOnce(ctx context.Context, m Module) (err error) {
+	var run = &moduleRun{}
+
+	ctx, run.cancel = context.WithCancel(ctx)
+	ctx = context.WithValue(ctx, moduleRunKey{}, run)
+
+	defer run.cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			shared.Logger.Debugf("Module '%s' panic stack trace:\n%s", m.MID(), debug.Stack())
+			err = errors.Errorf("panic: %v", r)
+		}
+	}()
+
+	if err = m.Start(ctx); err != nil {
+		return err
+	}
+
+	return run.failure()
+}
+
+func (s *Supervisor) shouldRestart(mid string, policy model.RestartPolicy, err error) bool {
+	if s.config.MaxRestarts > 0 {
+		s.mutex.Lock()
+		exceeded := s.health[mid].Restarts >= s.config.MaxRestarts
+		s.mutex.Unlock()
+
+		if exceeded {
+			shared.Logger.Errorf("Module '%s' exceeded %d restarts limit", mid, s.config.MaxRestarts)
+			return false
+		}
+	}
+
+	switch policy {
+	case model.RestartAlways:
+		return true
+	case model.RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// policy determines RestartPolicy for the module by given `mid`.
+func (s *Supervisor) policy(mid string) model.RestartPolicy {
+	// Viper keys are case-insensitive, so module IDs are stored lowercased:
+	if policy, ok := s.config.Modules[strings.ToLower(mid)]; ok {
+		return model.RestartPolicy(policy)
+	}
+
+	return model.RestartPolicy(s.config.RestartPolicy)
+}
+
+// update applies `change` to the module health state and notifies about it with events.ModuleHealthChanged.
+func (s *Supervisor) update(ctx context.Context, mid string, change func(h *model.ModuleHealth)) {
+	s.mutex.Lock()
+	health, ok := s.health[mid]
+	if !ok {
+		s.mutex.Unlock()
+		return
+	}
+
+	change(health)
+	snapshot := *health
+	s.mutex.Unlock()
+
+	eventdriver.EmitEvent(ctx, events.ModuleHealthChanged, events.ModuleHealthChangedPayload{
+		ModuleHealth: snapshot,
+	})
+}
+
+// Health returns health state of all tracked modules in order of their registration.
+func (s *Supervisor) Health() []model.ModuleHealth {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var health = make([]model.ModuleHealth, 0, len(s.order))
+
+	for _, mid := range s.order {
+		health = append(health, *s.health[mid])
+	}
+
+	return health
+}
+
+func (r *moduleRun) fail(err error) {
+	r.mutex.Lock()
+	if r.err == nil {
+		r.err = err
+	}
+	r.mutex.Unlock()
+
+	r.cancel()
+}
+
+func (r *moduleRun) failure() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.err
+}
+
+// Fail reports failure of the Module's routine run bound to `ctx`, e.g. from the event handler or spawned goroutine.
+// The run is stopped, so that Supervisor could restart it according to the RestartPolicy.
+func Fail(ctx context.Context, err error) {
+	if run, ok := ctx.Value(moduleRunKey{}).(*moduleRun); ok {
+		run.fail(err)
+		return
+	}
+
+	shared.Logger.Error(err)
+}
+
+// Go runs `routine` of the Module in a new goroutine under panic recovery,
+// where panic is reported as failure of the Module's run bound to `ctx`.
+func Go(ctx context.Context, routine func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				shared.Logger.Debugf("Module goroutine panic stack trace:\n%s", debug.Stack())
+				Fail(ctx, errors.Errorf("panic: %v", r))
+			}
+		}()
+
+		routine()
+	}()
+}
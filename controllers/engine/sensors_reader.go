@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -23,7 +24,10 @@ type (
 		sensors       sensor.SensorsRegister
 		requests      chan request
 		standbyTimers map[sensor.Sensor]*time.Timer
+		standby       int64
+		pending       *sync.WaitGroup
 		active        bool
+		done          <-chan struct{}
 		cancel        context.CancelFunc
 	}
 
@@ -48,6 +52,7 @@ func NewSensorsReader() *SensorsReader {
 		sensors:       make(map[string]sensor.Sensor),
 		requests:      make(chan request),
 		standbyTimers: make(map[sensor.Sensor]*time.Timer),
+		pending:       &sync.WaitGroup{},
 	}
 }
 // RegisteredSensors returns map with sensors registered on the engine.SensorsReader.
@@ -87,10 +92,14 @@ func (r *SensorsReader) SubscribeReceiver(
 	ctx, cancel := context.WithCancel(ctx)
 	go func(ctx context.Context) {
 		LOOP: for {
-			r.requests <- request{
+			select {
+			case r.requests <- request{
 				Metrics: metrics,
 				Handler: handler,
-			}
+			}:
+			case <- ctx.Done():
+				break LOOP
+			} // Engine may be stopped, so receiver must not be blocked on it once canceled.
 
 			select {
 			case <- ctx.Done():
@@ -105,23 +114,32 @@ func (r *SensorsReader) SubscribeReceiver(
 }
 
 // SendRequest creates single request for sensor readings that will be handled with given `handler`.
+// Request is dropped if the engine is stopped, so that caller won't be blocked on it.
 func (r *SensorsReader) SendRequest(handler ReceiverFunc, metrics ...models.Metric) {
-	r.requests <- request{
+	select {
+	case r.requests <- request{
 		Metrics: metrics,
 		Handler: handler,
+	}:
+	case <- r.done:
 	}
 }
 
 // Run starts working on the on the received requests by reading sensors data.
 func (r *SensorsReader) Run(ctx context.Context) {
 	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = ctx.Done()
 	r.active = true
 
 	go r.once.Do(func() {
 		for {
 			select {
 			case request := <- r.requests:
-				go r.handleRequest(ctx, request)
+				r.pending.Add(1)
+				go func() {
+					defer r.pending.Done()
+					r.handleRequest(ctx, request)
+				}()
 			case <- ctx.Done():
 				shared.Logger.Debug("Sensors reader engine routine ended")
 				return
@@ -136,15 +154,17 @@ func (r *SensorsReader) Active() bool {
 }
 
 // Close stops SensorReader working routine and clears allocated resources.
+// Requests being handled are cut short, but their results are still passed to the handlers, see Wait.
 func (r *SensorsReader) Close() {
 	r.active = false
-	r.cancel()
+
+	if r.cancel != nil {
+		r.cancel()
+	}
 
 	for _, s := range r.sensors {
-		if s.Active() {
-			if err := s.Close(); err != nil {
-				shared.Logger.Error(errors.Wrapf(err, "failed to close connection to '%s' sensor", s.ID()))
-			}
+		if _, err := sensor.CloseIdle(s); err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to close connection to '%s' sensor", s.ID()))
 		}
 	}
 }
@@ -183,6 +203,7 @@ func (r *SensorsReader) handleRequest(ctx context.Context, req request) {
 					// First time use initialization along with stand by handling:
 					if err := r.initSensor(sn); err != nil {
 						sensorCtx.Error(err)
+						waitGroup.Done()
 						return
 					}
 
@@ -214,15 +235,34 @@ func suitable(sensor sensor.Sensor, metric models.Metric) bool {
 	return false
 }
 
+// Wait blocks until requests being handled by the SensorsReader are done, including passing results to their handlers.
+func (r *SensorsReader) Wait() {
+	r.pending.Wait()
+}
+
+// SetStandbyTimeout sets duration of sensor inactivity after which it is put to sleep,
+// overriding the one specified in the configuration: viper.GetDuration("engine.sensor_sleep_standby_timeout").
+func (r *SensorsReader) SetStandbyTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&r.standby, int64(timeout))
+}
+
+func (r *SensorsReader) standbyTimeout() time.Duration {
+	if standby := atomic.LoadInt64(&r.standby); standby > 0 {
+		return time.Duration(standby)
+	}
+
+	return viper.GetDuration("engine.sensor_sleep_standby_timeout")
+}
+
+// initSensor leases sensor for reading, initializing it on first use, and schedules its standby.
+// Lease is returned once sensor is harvested by readSensor.
 func (r *SensorsReader) initSensor(sn sensor.Sensor) error {
 	var (
-		standby = viper.GetDuration("engine.sensor_sleep_standby_timeout")
+		standby = r.standbyTimeout()
 	)
 
-	if !sn.Active() {
-		if err := sn.Init(); err != nil {
-			return err
-		}
+	if err := sensor.Acquire(sn); err != nil {
+		return err
 	}
 
 	if timer, ok := r.standbyTimers[sn]; ok && timer != nil {
@@ -242,13 +282,17 @@ func (r *SensorsReader) readSensor(ctx *sensor.Context, sn sensor.Sensor, wg *sy
 
 	if !sn.Active() {
 		ctx.Warning("attempt of reading from non-active sensor")
+		sensor.Release(sn)
 
 		return
 	}
 
-	done := make(chan bool)
+	done := make(chan bool, 1)
 
 	go func() {
+		// Sensor is kept leased until it is harvested, even if reading is timed out:
+		defer sensor.Release(sn)
+
 		sn.Harvest(ctx)
 		done <- true
 	}()
@@ -267,9 +311,13 @@ func (r *SensorsReader) readSensor(ctx *sensor.Context, sn sensor.Sensor, wg *sy
 	}
 }
 
+// handleStandby closes sensor once timer `t` expires, unless it is still leased, e.g. by another reader.
 func handleStandby(t *time.Timer, sn sensor.Sensor) {
 	<-t.C
-	shared.Execute(sn.Close, fmt.Sprintf("failed to close connection to '%s' sensor", sn.ID()))
+	shared.Execute(func() error {
+		_, err := sensor.CloseIdle(sn)
+		return err
+	}, fmt.Sprintf("failed to close connection to '%s' sensor", sn.ID()))
 }
 
 func aggregate(pipe sensor.ReadingsPipe) ReadingResults {
@@ -290,13 +338,45 @@ func aggregate(pipe sensor.ReadingsPipe) ReadingResults {
 		}
 
 		if len(readings) != 0 {
-			results[metric] = selectResult(readings)
+			results[metric] = selectResult(preferred(metric, readings))
 		}
 	}
 
 	return results
 }
 
+// preferred filters out `readings` of lower sensor.Priority, if ones of higher priority are presented.
+// Filtered out readings are used to cross-check the preferred ones.
+func preferred(metric models.Metric, readings []sensor.ReadingResult) []sensor.ReadingResult {
+	var (
+		top = readings[0].Priority
+		filtered []sensor.ReadingResult
+	)
+
+	for i := range readings {
+		if readings[i].Priority > top {
+			top = readings[i].Priority
+		}
+	}
+
+	for i := range readings {
+		if readings[i].Priority == top {
+			filtered = append(filtered, readings[i])
+		}
+	}
+
+	for i := range readings {
+		if readings[i].Priority == top {
+			continue
+		}
+
+		shared.Logger.Debugf("Cross-check for '%s' metric: %s reading %.2f is dismissed in favor of %s reading %.2f",
+			metric, readings[i].Source, readings[i].Value, filtered[0].Source, filtered[0].Value)
+	}
+
+	return filtered
+}
+
 func selectResult(results []sensor.ReadingResult) (result float64) {
 	var (
 		getPrecision = func(v float64) int {
@@ -0,0 +1,124 @@
+package gui
+
+import (
+	"bytes"
+	"embed"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	scale "golang.org/x/image/draw"
+)
+
+// embeddedAssets contains default GUI icons compiled into the binary,
+// so that rendering doesn't depend on the working directory.
+//
+//go:embed assets/*.png
+var embeddedAssets embed.FS
+
+type iconKey struct {
+	name   string
+	scale  float64
+	dither bool
+}
+
+var (
+	icons       = make(map[iconKey]image.Image)
+	iconsLock   = &sync.Mutex{}
+	monoPalette = color.Palette{color.Transparent, color.Black}
+)
+
+// loadIcon returns cached `icon` image prepared for the current display:
+// scaled with respect of the frame size and dithered in case display is monochrome.
+func loadIcon(icon string) (image.Image, error) {
+	key := iconKey{
+		name:   icon,
+		scale:  layoutScale(),
+		dither: !colored(),
+	}
+
+	iconsLock.Lock()
+	defer iconsLock.Unlock()
+
+	if img, ok := icons[key]; ok {
+		return img, nil
+	}
+
+	data, err := readAsset(iconPath(icon))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read '%s' icon", icon)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode '%s' icon", icon)
+	}
+
+	img = scaleIcon(img, key.scale)
+
+	if key.dither {
+		img = ditherIcon(img)
+	}
+
+	icons[key] = img
+
+	return img, nil
+}
+
+// readAsset reads asset by its `path` relative to the assets directory.
+// Files from `gui.assets_dir` directory take precedence over the embedded ones, thus allowing custom branding.
+func readAsset(path string) ([]byte, error) {
+	if dir := viper.GetString("gui.assets_dir"); len(dir) != 0 {
+		data, err := ioutil.ReadFile(filepath.Join(dir, path))
+		if err == nil {
+			return data, nil
+		}
+
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return embeddedAssets.ReadFile(filepath.ToSlash(filepath.Join("assets", path)))
+}
+
+// scaleIcon scales `img` by `factor`, unless it is 1.
+func scaleIcon(img image.Image, factor float64) image.Image {
+	if factor == 1 {
+		return img
+	}
+
+	var (
+		b      = img.Bounds()
+		scaled = image.NewRGBA(image.Rect(0, 0,
+			int(math.Round(float64(b.Dx())*factor)),
+			int(math.Round(float64(b.Dy())*factor)),
+		))
+	)
+
+	// Nearest neighbor keeps pixels sharp, which is important for monochrome displays:
+	scale.NearestNeighbor.Scale(scaled, scaled.Bounds(), img, b, scale.Over, nil)
+
+	return scaled
+}
+
+// ditherIcon reduces `img` to opaque black and transparent pixels with Floyd-Steinberg dithering,
+// so that anti-aliased edges and semi-transparent shades aren't lost on 1-bit displays.
+func ditherIcon(img image.Image) image.Image {
+	dithered := image.NewPaletted(img.Bounds(), monoPalette)
+	draw.FloydSteinberg.Draw(dithered, dithered.Bounds(), img, img.Bounds().Min)
+
+	return dithered
+}
+
+func iconPath(icon string) string {
+	return icon + ".png"
+}
@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// Font defines typeface used for rendering text.
+type Font string
+
+const (
+	// RegularFont is a Go Regular typeface.
+	RegularFont Font = "regular"
+	// MediumFont is a Go Medium typeface.
+	MediumFont Font = "medium"
+)
+
+type faceKey struct {
+	font Font
+	size float64
+}
+
+var (
+	fontsTTF = map[Font][]byte{
+		RegularFont: goregular.TTF,
+		MediumFont:  gomedium.TTF,
+	}
+
+	parsedFonts = make(map[Font]*truetype.Font)
+	fontFaces   = make(map[faceKey]font.Face)
+	fontsLock   = &sync.Mutex{}
+)
+
+// fontFace returns cached font face of `f` typeface with `size` in points,
+// so that fonts are parsed and faces are created only once.
+func fontFace(f Font, size float64) font.Face {
+	fontsLock.Lock()
+	defer fontsLock.Unlock()
+
+	key := faceKey{font: f, size: size}
+	if face, ok := fontFaces[key]; ok {
+		return face
+	}
+
+	parsed, ok := parsedFonts[f]
+	if !ok {
+		var err error
+		if parsed, err = truetype.Parse(fontData(f)); err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to parse '%s' font", f))
+			return basicfont.Face7x13
+		}
+
+		parsedFonts[f] = parsed
+	}
+
+	face := truetype.NewFace(parsed, &truetype.Options{
+		Size: size,
+	})
+
+	fontFaces[key] = face
+
+	return face
+}
+
+// fontData returns TrueType data of `f` typeface.
+// Font file `fonts/<name>.ttf` from `gui.assets_dir` directory takes precedence over the built-in Go fonts.
+func fontData(f Font) []byte {
+	if dir := viper.GetString("gui.assets_dir"); len(dir) != 0 {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "fonts", string(f)+".ttf"))
+		if err == nil {
+			return data
+		}
+
+		if !os.IsNotExist(err) {
+			shared.Logger.Warning(errors.Wrapf(err, "failed to read '%s' font override", f))
+		}
+	}
+
+	return fontsTTF[f]
+}
@@ -0,0 +1,143 @@
+package gui
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	core "github.com/timoth-y/chainmetric-iot/core/dev"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+var (
+	lastFrame      *image.RGBA
+	lastFrameTime  time.Time
+	pendingFrame   *image.RGBA
+	partialUpdates int
+	frameLock      = &sync.Mutex{}
+)
+
+// scheduleFrame displays `frame` right away if frame rate limit allows it,
+// otherwise it will be displayed once the minimal frame interval passes, unless superseded by a newer one.
+func scheduleFrame(frame *image.RGBA) {
+	frameLock.Lock()
+	defer frameLock.Unlock()
+
+	delay := time.Until(lastFrameTime.Add(frameInterval()))
+	if delay <= 0 {
+		showFrame(frame)
+		return
+	}
+
+	if pendingFrame == nil {
+		time.AfterFunc(delay, Flush)
+	}
+
+	pendingFrame = frame
+}
+
+// Flush displays pending frame right away regardless of the frame rate limit,
+// which is needed when frame must be shown before display is turned off.
+func Flush() {
+	frameLock.Lock()
+	defer frameLock.Unlock()
+
+	if pendingFrame != nil {
+		showFrame(pendingFrame)
+		pendingFrame = nil
+	}
+}
+
+func showFrame(frame *image.RGBA) {
+	shared.MustExecute(func() error {
+		return drawFrame(frame)
+	}, "failed to draw and refresh frame")
+
+	// Interval is counted from the moment display finished updating, which can take a while for e-ink:
+	lastFrameTime = time.Now()
+}
+
+// frameInterval determines minimal interval between frames based on `display.frame_rate` frames per second.
+func frameInterval() time.Duration {
+	rate := viper.GetInt("display.frame_rate")
+	if rate <= 0 {
+		return 0
+	}
+
+	return time.Second / time.Duration(rate)
+}
+
+// drawFrame sends `frame` to the display device, skipping it if nothing changed since the last frame.
+// For devices supporting partial update only the changed region is sent,
+// while the full refresh is forced every `display.full_refresh_interval` partial updates to clear ghosting.
+func drawFrame(frame *image.RGBA) error {
+	var (
+		changed            = frameDiff(lastFrame, frame)
+		pd, partialCapable = dev.(core.PartialDisplay)
+		fullRefreshEvery   = viper.GetInt("display.full_refresh_interval")
+	)
+
+	if changed.Empty() {
+		return nil
+	}
+
+	if partialCapable && lastFrame != nil && partialUpdates < fullRefreshEvery {
+		if err := pd.DrawPartial(frame, changed); err != nil {
+			return err
+		}
+
+		partialUpdates++
+	} else {
+		if err := dev.DrawAndRefresh(frame); err != nil {
+			return err
+		}
+
+		partialUpdates = 0
+	}
+
+	lastFrame = frame
+
+	return nil
+}
+
+// frameDiff determines the smallest rectangle containing all pixels differing between `prev` and `next` frames.
+// Whole `next` frame bounds are returned if there is no previous frame or its bounds differ.
+func frameDiff(prev, next *image.RGBA) image.Rectangle {
+	if prev == nil || !prev.Bounds().Eq(next.Bounds()) {
+		return next.Bounds()
+	}
+
+	var (
+		b    = next.Bounds()
+		diff = image.Rectangle{}
+	)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if prev.RGBAAt(x, y) != next.RGBAAt(x, y) {
+				diff = diff.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return diff
+}
+
+// resetFrame discards the last displayed frame, so that the next one will be fully refreshed.
+func resetFrame() {
+	frameLock.Lock()
+	defer frameLock.Unlock()
+
+	lastFrame = nil
+	pendingFrame = nil
+	partialUpdates = 0
+}
+
+func cloneFrame(frame image.Image) *image.RGBA {
+	clone := image.NewRGBA(frame.Bounds())
+	draw.Draw(clone, clone.Bounds(), frame, frame.Bounds().Min, draw.Src)
+
+	return clone
+}
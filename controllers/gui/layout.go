@@ -0,0 +1,262 @@
+package gui
+
+import (
+	"image"
+	"math"
+)
+
+const (
+	// referenceWidth and referenceHeight define frame size for which GUI layouts were originally designed.
+	referenceWidth  = 250
+	referenceHeight = 122
+	// minLayoutScale restricts downscaling of fonts and icons, so that text stays legible on small displays.
+	minLayoutScale = 0.6
+)
+
+// layoutScale determines scale factor of the fonts and icons for the current frame size.
+// Larger frames keep original sizes, while smaller ones (e.g. 128x64 OLED) are scaled down.
+func layoutScale() float64 {
+	scale := math.Min(
+		float64(frameWidth)/referenceWidth,
+		float64(frameHeight)/referenceHeight,
+	)
+
+	return math.Max(minLayoutScale, math.Min(1, scale))
+}
+
+// compactLayout checks whether the current frame is too small for original layouts.
+func compactLayout() bool {
+	return layoutScale() < 1
+}
+
+// fontSize scales `size` in points with respect of the current frame size.
+func fontSize(size float64) float64 {
+	return math.Round(size * layoutScale())
+}
+
+// Widget defines GUI element, which can be measured and drawn within the given frame region.
+type Widget interface {
+	// Measure returns preferred size of the Widget, which fits into `max` size.
+	Measure(max image.Point) image.Point
+	// Draw draws the Widget within `r` region of the frame.
+	Draw(r image.Rectangle)
+}
+
+// Render draws `root` Widget on the whole frame and displays it.
+func Render(root Widget) {
+	initContext()
+
+	root.Draw(image.Rect(0, 0, frameWidth, frameHeight))
+
+	ShowFrame()
+}
+
+type axis int
+
+const (
+	horizontal axis = iota
+	vertical
+)
+
+// flexBox is a layout Widget, which arranges its children along the main axis one after another
+// and stretches them along the cross axis. The space left is shared equally by the expanded children.
+type flexBox struct {
+	axis     axis
+	spacing  int
+	children []Widget
+}
+
+// Column arranges `children` widgets vertically.
+func Column(children ...Widget) Widget {
+	return flexBox{axis: vertical, spacing: int(fontSize(2)), children: children}
+}
+
+// Row arranges `children` widgets horizontally.
+func Row(children ...Widget) Widget {
+	return flexBox{axis: horizontal, spacing: int(fontSize(4)), children: children}
+}
+
+func (b flexBox) Measure(max image.Point) image.Point {
+	var (
+		sizes, expanded = b.measureChildren(max)
+		main, cross     int
+	)
+
+	for i, child := range b.children {
+		var size = sizes[i]
+
+		if _, ok := child.(expandedWidget); ok {
+			size = child.Measure(max)
+		} else {
+			main += b.main(size)
+		}
+
+		if c := b.cross(size); c > cross {
+			cross = c
+		}
+	}
+
+	main += b.spacing * maxInt(len(b.children)-1, 0)
+
+	if expanded > 0 {
+		main = b.main(max)
+	}
+
+	return b.point(main, minInt(cross, b.cross(max)))
+}
+
+func (b flexBox) Draw(r image.Rectangle) {
+	var (
+		sizes, expanded = b.measureChildren(r.Size())
+		fixed           = b.spacing * maxInt(len(b.children)-1, 0)
+		offset          = b.main(r.Min)
+	)
+
+	for i := range b.children {
+		fixed += b.main(sizes[i])
+	}
+
+	for i, child := range b.children {
+		size := b.main(sizes[i])
+
+		if _, ok := child.(expandedWidget); ok {
+			size = maxInt(b.main(r.Size())-fixed, 0) / expanded
+		}
+
+		if b.axis == vertical {
+			child.Draw(image.Rect(r.Min.X, offset, r.Max.X, offset+size))
+		} else {
+			child.Draw(image.Rect(offset, r.Min.Y, offset+size, r.Max.Y))
+		}
+
+		offset += size + b.spacing
+	}
+}
+
+// measureChildren measures non-expanded children in order, each one within the space left by the previous ones,
+// and returns their sizes along with the count of the expanded children.
+func (b flexBox) measureChildren(max image.Point) ([]image.Point, int) {
+	var (
+		sizes    = make([]image.Point, len(b.children))
+		left     = b.main(max)
+		expanded int
+	)
+
+	for i, child := range b.children {
+		if _, ok := child.(expandedWidget); ok {
+			expanded++
+			continue
+		}
+
+		sizes[i] = child.Measure(b.point(maxInt(left, 0), b.cross(max)))
+		left -= b.main(sizes[i]) + b.spacing
+	}
+
+	return sizes, expanded
+}
+
+func (b flexBox) main(p image.Point) int {
+	if b.axis == vertical {
+		return p.Y
+	}
+
+	return p.X
+}
+
+func (b flexBox) cross(p image.Point) int {
+	if b.axis == vertical {
+		return p.X
+	}
+
+	return p.Y
+}
+
+func (b flexBox) point(main, cross int) image.Point {
+	if b.axis == vertical {
+		return image.Pt(cross, main)
+	}
+
+	return image.Pt(main, cross)
+}
+
+// expandedWidget wraps Widget to take all the space left in Row or Column.
+type expandedWidget struct {
+	Widget
+}
+
+// Expanded makes `w` Widget take all the space left in the parent Row or Column.
+func Expanded(w Widget) Widget {
+	return expandedWidget{Widget: w}
+}
+
+// Spacer is an empty Widget, which is intended to be used as Expanded to push siblings apart.
+func Spacer() Widget {
+	return Expanded(spacer{})
+}
+
+type spacer struct{}
+
+func (spacer) Measure(image.Point) image.Point {
+	return image.Point{}
+}
+
+func (spacer) Draw(image.Rectangle) {}
+
+// centered is a layout Widget, which draws its child with preferred size in the center of the region.
+type centered struct {
+	child Widget
+}
+
+// Center draws `w` Widget in the center of the available space.
+func Center(w Widget) Widget {
+	return centered{child: w}
+}
+
+func (c centered) Measure(max image.Point) image.Point {
+	return max
+}
+
+func (c centered) Draw(r image.Rectangle) {
+	var (
+		size   = c.child.Measure(r.Size())
+		offset = r.Size().Sub(size).Div(2)
+	)
+
+	c.child.Draw(image.Rectangle{Min: r.Min.Add(offset), Max: r.Min.Add(offset).Add(size)})
+}
+
+// padded is a layout Widget, which adds empty space around its child.
+type padded struct {
+	child   Widget
+	padding int
+}
+
+// Padding adds `padding` pixels (scaled with respect of the frame size) around `w` Widget.
+func Padding(w Widget, padding float64) Widget {
+	return padded{child: w, padding: int(fontSize(padding))}
+}
+
+func (p padded) Measure(max image.Point) image.Point {
+	inset := image.Pt(p.padding*2, p.padding*2)
+	return p.child.Measure(max.Sub(inset)).Add(inset)
+}
+
+func (p padded) Draw(r image.Rectangle) {
+	p.child.Draw(r.Inset(p.padding))
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,99 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// ReadingStatus defines whether the reading value satisfies its requirement.
+type ReadingStatus int
+
+const (
+	// ReadingUnknown identifies reading, which value wasn't received yet.
+	ReadingUnknown ReadingStatus = iota
+	// ReadingInRange identifies reading, which value is within the required limits.
+	ReadingInRange
+	// ReadingOutOfRange identifies reading, which value violates the required limits.
+	ReadingOutOfRange
+)
+
+// Reading defines single row of the ReadingsTable.
+type Reading struct {
+	Label  string
+	Value  string
+	Status ReadingStatus
+}
+
+// ReadingsTable is a Widget displaying Rows of labeled values with in range or out of range markers,
+// which takes all available space. Rows which don't fit are replaced with the count of the remaining ones.
+type ReadingsTable struct {
+	Rows []Reading
+}
+
+func (t ReadingsTable) Measure(max image.Point) image.Point {
+	return max
+}
+
+func (t ReadingsTable) Draw(r image.Rectangle) {
+	if len(t.Rows) == 0 {
+		List{}.Draw(r)
+		return
+	}
+
+	var (
+		y           = r.Min.Y
+		markerWidth = Text{Content: "OUT", Font: MediumFont, Size: 13}.Measure(r.Size()).X
+		spacing     = int(fontSize(4))
+	)
+
+	for i, row := range t.Rows {
+		var (
+			label = Text{Content: row.Label, Size: 13}
+			lh    = label.Measure(r.Size()).Y + int(fontSize(lineSpacing))
+		)
+
+		// Last row which fits is replaced with remaining rows count, if there are more of them:
+		if i < len(t.Rows)-1 && y+2*lh > r.Max.Y {
+			label.Content = fmt.Sprintf("... and %d more", len(t.Rows)-i)
+			label.Draw(image.Rect(r.Min.X, y, r.Max.X, y+lh))
+			break
+		}
+
+		var (
+			text, accent = row.marker()
+			value        = Text{Content: row.Value, Size: 13, Align: AlignRight}
+			markerMin    = r.Max.X - markerWidth
+			valueMin     = markerMin - spacing - value.Measure(r.Size()).X
+		)
+
+		label.Draw(image.Rect(r.Min.X, y, valueMin-spacing, y+lh))
+		value.Draw(image.Rect(valueMin, y, markerMin-spacing, y+lh))
+		Text{Content: text, Font: MediumFont, Size: 13, Color: accent, Align: AlignCenter}.
+			Draw(image.Rect(markerMin, y, r.Max.X, y+lh))
+
+		y += lh
+	}
+}
+
+// marker returns text and color of the Reading status marker.
+// Text is used along with color, so that status is distinguishable on monochrome displays.
+func (r Reading) marker() (string, color.Color) {
+	switch r.Status {
+	case ReadingInRange:
+		return "OK", currentTheme.Success
+	case ReadingOutOfRange:
+		return "OUT", currentTheme.Error
+	default:
+		return "--", currentTheme.Foreground
+	}
+}
+
+// RenderReadings displays frame with `title` and table of `readings` below it.
+func RenderReadings(title string, readings ...Reading) {
+	Render(Padding(Column(
+		StatusBar{Title: title},
+		Expanded(ReadingsTable{Rows: readings}),
+		PageIndicator{},
+	), 2))
+}
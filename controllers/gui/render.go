@@ -1,21 +1,13 @@
 package gui
 
 import (
-	"bytes"
 	"fmt"
-	"image"
+	"image/color"
 	"math"
-	"strings"
 
 	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
-	"github.com/pkg/errors"
-	"github.com/skip2/go-qrcode"
 	core "github.com/timoth-y/chainmetric-iot/core/dev"
 	"github.com/timoth-y/chainmetric-iot/shared"
-	"github.com/wcharczuk/go-chart"
-	"golang.org/x/image/font/gofont/gomedium"
-	"golang.org/x/image/font/gofont/goregular"
 )
 
 var (
@@ -28,95 +20,36 @@ var (
 // Init initialises GUI agent.
 func Init(display core.Display) {
 	dev = display
+	currentTheme = selectTheme(display)
+	resetFrame()
 	initContext()
 }
 
 // RenderText  displays frame with `msg` text.
 func RenderText(msg string) {
-	initContext()
-
-	font, err := truetype.Parse(gomedium.TTF)
-	if err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to parse font"))
-	}
-
-	face := truetype.NewFace(font, &truetype.Options{
-		Size: 18,
-	})
-	ctx.SetFontFace(face)
-
-	var (
-		vIndent = 0.0
-	)
-
-	for _, line := range strings.Split(msg, "\n") {
-		tw, th := ctx.MeasureString(line)
-		ctx.DrawString(line, float64(frameWidth/ 2) - tw / 2, float64(frameHeight/ 2) + th / 2 + vIndent)
-		vIndent += th + 5
-	}
-
-	ShowFrame()
+	Render(Center(Text{Content: msg, Font: MediumFont, Size: 18, Align: AlignCenter}))
 }
 
 // RenderWithChart displays frame with chart and `msg` text.
+// Chart takes all the space left below the text.
 func RenderWithChart(msg string, v ...float64) {
-	initContext()
-
-	font, err := truetype.Parse(gomedium.TTF)
-	if err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to parse font"))
-	}
-
-	face := truetype.NewFace(font, &truetype.Options{
-		Size: 14,
-	})
-	ctx.SetFontFace(face)
-
-	var vIndent = 0.0
-
-	for _, line := range strings.Split(msg, "\n") {
-		_, th := ctx.MeasureString(line)
-		ctx.DrawString(line, 0, 0 + th + vIndent)
-		vIndent += th + 2
-	}
-
-	xValues := make([]float64, len(v))
-	for i := range v {
-		xValues[i] = float64(i) + 1
-	}
-
-	chart.DefaultFillColor = chart.ColorBlack
-	graph := chart.Chart{
-		Series: []chart.Series{
-			chart.ContinuousSeries{
-				XValues: xValues,
-				YValues: v,
-				Style: chart.Style{
-					Show: true,
-					StrokeWidth: 3,
-					DotColor: chart.ColorBlack,
-					StrokeColor: chart.ColorBlack,
-				},
-			},
-		},
-		Height: frameHeight / 2,
-		Width: frameWidth,
-	}
-
-	buffer := bytes.NewBuffer([]byte{})
-	if err = graph.Render(chart.PNG, buffer); err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to render chart"))
-	}
-
-	if img, _, err := image.Decode(buffer); err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to decode chart image"))
-	} else {
-		ctx.DrawImage(img, 0, int(float64(frameHeight/ 2)))
-	}
-
-	renderBatteryLevel()
+	Render(Column(
+		Row(
+			Expanded(Text{Content: msg, Font: MediumFont}),
+			Battery{},
+		),
+		Expanded(Chart{Values: v}),
+	))
+}
 
-	ShowFrame()
+// RenderList displays frame with `title` and `lines` list below it.
+// Lines which don't fit into the frame are replaced with the count of the remaining ones.
+func RenderList(title string, lines ...string) {
+	Render(Padding(Column(
+		StatusBar{Title: title},
+		Expanded(List{Lines: lines}),
+		PageIndicator{},
+	), 2))
 }
 
 func RenderTextf(format string, a ...interface{}) {
@@ -125,91 +58,48 @@ func RenderTextf(format string, a ...interface{}) {
 
 // RenderTextWithIcon displays frame with `msg` text and `icon` image.
 func RenderTextWithIcon(text, icon string) {
-	initContext()
-
-	var (
-		iconImg image.Image
-		iconPath = iconPath(icon)
-		font, err = truetype.Parse(goregular.TTF)
-		face = truetype.NewFace(font, &truetype.Options{
-			Size: 14,
-		})
-	)
-
-	if err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to create font face"))
-		return
-	}
-
-	ctx.SetFontFace(face)
-
-	if iconImg, err = gg.LoadPNG(iconPath); err != nil {
-		shared.Logger.Error(errors.Wrapf(err, "failed to load from path '%s'", iconPath))
-		return
-	}
-
-	var (
-		ib = iconImg.Bounds()
-		ix = int(math.Round(float64(frameWidth/ 2) - float64(ib.Dx()) / 2))
-		iy = int(math.Round(float64(frameHeight / 2))) - ib.Dy() / 2
-
-		vIndent = 0.0
-	)
-
-	for _, line := range strings.Split(text, "\n") {
-		var (
-			tw, th  = ctx.MeasureString(line)
-
-			tx = float64(frameWidth/ 2) - tw / 2
-			ty = float64(frameHeight/ 2 - ib.Dy() / 2) - th
-		)
-
-		ctx.DrawString(line, tx, ty + th + vIndent)
-
-		vIndent += th + 2
-		iy += int(th) + 2
-	}
-
-	ctx.DrawImage(iconImg, ix, iy)
+	renderTextWithIcon(text, icon, currentTheme.Foreground)
+}
 
-	ShowFrame()
+func renderTextWithIcon(text, icon string, accent color.Color) {
+	Render(Center(Column(
+		Text{Content: text, Align: AlignCenter},
+		Icon{Name: icon, Color: accent},
+	)))
 }
 
 // RenderSuccessMsg displays frame with `msg` text and "success" icon.
 func RenderSuccessMsg(msg string) {
-	RenderTextWithIcon(msg, "success")
+	renderTextWithIcon(msg, "success", currentTheme.Success)
 }
 
 // RenderWarningMsg displays frame with `msg` and "warning" icon.
 func RenderWarningMsg(msg string) {
-	RenderTextWithIcon(msg, "warning")
+	renderTextWithIcon(msg, "warning", currentTheme.Warning)
 }
 
 // RenderErrorMsg displays frame with `msg` text and "error" icon.
 func RenderErrorMsg(msg string) {
-	RenderTextWithIcon(msg, "error")
+	renderTextWithIcon(msg, "error", currentTheme.Error)
 }
 
 // RenderQRCode displays frame with QR code of `data`.
+// On small displays QR code is rendered without quiet zone border and with lower error recovery level when needed.
 func RenderQRCode(data string) {
-	qr, err := qrcode.New(data, qrcode.Medium); if err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to create QR code image"))
+	initContext()
+
+	if _, err := qrImage(data, int(math.Min(float64(frameWidth), float64(frameHeight)))); err != nil {
+		shared.Logger.Error(err)
+		RenderErrorMsg("QR code is too large\nfor this display")
 		return
 	}
 
-	var (
-		qrImg = qr.Image(frameHeight)
-		x = int(math.Round(float64(frameWidth/ 2) - float64(qrImg.Bounds().Dx()) / 2))
-		y = int(math.Round(float64(frameHeight/ 2) - float64(qrImg.Bounds().Dy()) / 2))
-	)
-
-	ctx.DrawImage(qrImg, x, y)
-	ctx.Fill()
-
-	ShowFrame()
+	Render(Center(QRCode{Data: data}))
 }
 
 // ShowFrame displays frame with rendered context.
+// Frames are limited by `display.frame_rate`, so the one rendered too soon is deferred
+// and superseded by any newer frame rendered in the meantime.
 func ShowFrame() {
 	ctx.Fill()
 
@@ -217,9 +107,7 @@ func ShowFrame() {
 		return
 	}
 
-	shared.MustExecute(func() error {
-		return dev.DrawAndRefresh(ctx.Image())
-	}, "failed to draw and refresh frame")
+	scheduleFrame(cloneFrame(ctx.Image()))
 }
 
 // Available checks whether the GUI is available.
@@ -236,11 +124,7 @@ func initContext() {
 }
 
 func clearFrame() {
-	ctx.SetRGB(1, 1, 1)
+	ctx.SetColor(currentTheme.Background)
 	ctx.Clear()
-	ctx.SetRGB(0, 0, 0)
-}
-
-func iconPath(icon string) string {
-	return fmt.Sprintf("controllers/gui/assets/%s.png", icon)
+	ctx.SetColor(currentTheme.Foreground)
 }
@@ -0,0 +1,115 @@
+package gui
+
+import (
+	"flag"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"periph.io/x/periph/devices/ssd1306/image1bit"
+
+	"github.com/timoth-y/chainmetric-iot/drivers/display"
+)
+
+// displayProfile defines display device GUI snapshots are taken on.
+type displayProfile struct {
+	name          string
+	width, height int
+	model         color.Model
+}
+
+var profiles = []displayProfile{
+	{name: "eink", width: 250, height: 122, model: image1bit.BitModel},
+	{name: "lcd", width: 240, height: 240, model: color.RGBAModel},
+	{name: "oled", width: 128, height: 64, model: image1bit.BitModel},
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	// Frames must be displayed right away, so that they can be taken from display after render call:
+	viper.Set("display.frame_rate", 0)
+
+	os.Exit(m.Run())
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		name   string
+		render func()
+	}{
+		{"text", func() {
+			RenderText("Device is online")
+		}},
+		{"text_multiline", func() {
+			RenderTextf("Registered as %s\nwaiting for assets", "device-01")
+		}},
+		{"chart", func() {
+			SetBatteryLevel(76)
+			RenderWithChart("IP: 192.168.1.10\nSupported: 12 metrics\nThoughput: 4 requests\\min",
+				1, 4, 2, 8, 5, 7)
+		}},
+		{"success", func() {
+			RenderSuccessMsg("Device registered")
+		}},
+		{"warning", func() {
+			RenderWarningMsg("Sensors not found")
+		}},
+		{"error", func() {
+			RenderErrorMsg("Failed to connect")
+		}},
+		{"icon", func() {
+			RenderTextWithIcon("Bluetooth pairing started...", "bluetooth")
+		}},
+		{"qr", func() {
+			RenderQRCode(`{"hostname":"chainmetric-device","ip_address":"192.168.1.10"}`)
+		}},
+		{"list", func() {
+			SetScreens(Screen{Name: "overview"}, Screen{Name: "sensors"}, Screen{Name: "network"})
+			NextScreen()
+			RenderList("Sensors", "BME280", "SCD30", "LSM303C", "ADXL345", "MAX44009", "SI1145", "HDC1080", "CCS811")
+		}},
+		{"battery_low", func() {
+			SetBatteryLevel(12)
+			RenderList("Network", "IP: 192.168.1.10", "Ledger: online")
+		}},
+		{"readings", func() {
+			RenderReadings("Pallet 7 (2/3)",
+				Reading{Label: "humidity", Value: "45.20", Status: ReadingInRange},
+				Reading{Label: "luminosity", Value: "n/a", Status: ReadingUnknown},
+				Reading{Label: "temperature", Value: "31.70", Status: ReadingOutOfRange},
+			)
+		}},
+	}
+
+	for _, profile := range profiles {
+		for _, c := range cases {
+			name := profile.name + "_" + c.name
+
+			t.Run(name, func(t *testing.T) {
+				mem := setupDisplay(t, profile)
+
+				c.render()
+
+				assertSnapshot(t, name, mem.Frame())
+			})
+		}
+	}
+}
+
+// setupDisplay initialises GUI with in-memory display of the `profile` and resets GUI state left by previous tests.
+func setupDisplay(t *testing.T, profile displayProfile) *display.Memory {
+	t.Helper()
+
+	mem := display.NewMemory(profile.width, profile.height, profile.model)
+	if err := mem.Init(); err != nil {
+		t.Fatalf("failed to initialize memory display: %v", err)
+	}
+
+	Init(mem)
+	SetScreens()
+	SetBatteryLevel(100)
+
+	return mem
+}
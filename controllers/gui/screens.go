@@ -0,0 +1,126 @@
+package gui
+
+import (
+	"sync"
+	"time"
+)
+
+// Screen defines GUI page, which can be navigated to with buttons.
+type Screen struct {
+	// Name identifies the Screen.
+	Name string
+	// Render displays the Screen content.
+	Render func()
+	// Action is optionally performed on long press of the select button while the Screen is displayed.
+	Action func()
+}
+
+var (
+	screens         []Screen
+	currentScreen   int
+	lastInteraction time.Time
+	screensLock     = &sync.Mutex{}
+)
+
+// SetScreens defines Screen pages available for navigation, where the first one is the home (overview) Screen.
+func SetScreens(pages ...Screen) {
+	screensLock.Lock()
+	defer screensLock.Unlock()
+
+	screens = pages
+	currentScreen = 0
+	lastInteraction = time.Now()
+}
+
+// CurrentScreen returns currently displayed Screen.
+func CurrentScreen() (Screen, bool) {
+	screensLock.Lock()
+	defer screensLock.Unlock()
+
+	if len(screens) == 0 {
+		return Screen{}, false
+	}
+
+	return screens[currentScreen], true
+}
+
+// RenderCurrentScreen displays currently selected Screen.
+func RenderCurrentScreen() {
+	if screen, ok := CurrentScreen(); ok && screen.Render != nil {
+		screen.Render()
+	}
+}
+
+// NextScreen selects the next Screen, going around to the first one after the last.
+func NextScreen() {
+	navigate(func(i, n int) int {
+		return (i + 1) % n
+	})
+}
+
+// PreviousScreen selects the previous Screen, going around to the last one before the first.
+func PreviousScreen() {
+	navigate(func(i, n int) int {
+		return (i - 1 + n) % n
+	})
+}
+
+// HomeScreen selects the home (overview) Screen.
+// Use RenderCurrentScreen() to display selected Screen.
+func HomeScreen() {
+	navigate(func(_, _ int) int {
+		return 0
+	})
+}
+
+// TriggerScreenAction performs action of the current Screen if it has one.
+func TriggerScreenAction() {
+	touch()
+
+	if screen, ok := CurrentScreen(); ok && screen.Action != nil {
+		screen.Action()
+	}
+}
+
+// ResetIfIdle selects the home Screen if there was no interaction for the `timeout` duration,
+// and reports whether the selection was changed.
+func ResetIfIdle(timeout time.Duration) bool {
+	screensLock.Lock()
+	idle := currentScreen != 0 && time.Since(lastInteraction) >= timeout
+	screensLock.Unlock()
+
+	if idle {
+		HomeScreen()
+	}
+
+	return idle
+}
+
+func navigate(next func(i, n int) int) {
+	screensLock.Lock()
+
+	if len(screens) == 0 {
+		screensLock.Unlock()
+		return
+	}
+
+	currentScreen = next(currentScreen, len(screens))
+	lastInteraction = time.Now()
+
+	screensLock.Unlock()
+}
+
+func touch() {
+	screensLock.Lock()
+	defer screensLock.Unlock()
+
+	lastInteraction = time.Now()
+}
+
+// screenPosition returns index of the current Screen and total count of the Screen pages.
+func screenPosition() (int, int) {
+	screensLock.Lock()
+	defer screensLock.Unlock()
+
+	return currentScreen, len(screens)
+}
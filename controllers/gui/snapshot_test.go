@@ -0,0 +1,149 @@
+package gui
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden snapshots of GUI frames")
+
+const (
+	// snapshotsDir is a directory where golden snapshots are committed.
+	snapshotsDir = "testdata"
+	// failedSnapshotsDir is a directory where actual frames and diff images are written on failure.
+	failedSnapshotsDir = "testdata/failed"
+	// channelTolerance defines maximum difference of color channel for pixels to be considered equal,
+	// so that minor anti-aliasing variations aren't reported.
+	channelTolerance = 0x10
+	// pixelsTolerance defines maximum share of differing pixels for frames to be considered equal.
+	pixelsTolerance = 0.002
+)
+
+// assertSnapshot compares `frame` with golden snapshot stored by `name`.
+// With `-update` flag golden snapshot is overwritten instead.
+// On mismatch actual frame and diff image highlighting differing pixels are written to failedSnapshotsDir.
+func assertSnapshot(t *testing.T, name string, frame image.Image) {
+	t.Helper()
+
+	golden := filepath.Join(snapshotsDir, name+".png")
+
+	if *update {
+		if err := writePNG(golden, frame); err != nil {
+			t.Fatalf("failed to update golden snapshot: %v", err)
+		}
+
+		return
+	}
+
+	expected, err := readPNG(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden snapshot, run tests with -update flag to create it: %v", err)
+	}
+
+	diff, mismatched := compareFrames(expected, frame)
+	if mismatched <= pixelsTolerance {
+		return
+	}
+
+	var (
+		actualPath = filepath.Join(failedSnapshotsDir, name+".actual.png")
+		diffPath   = filepath.Join(failedSnapshotsDir, name+".diff.png")
+	)
+
+	if err := writePNG(actualPath, frame); err != nil {
+		t.Errorf("failed to write actual frame: %v", err)
+	}
+
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Errorf("failed to write diff image: %v", err)
+	}
+
+	t.Errorf("frame differs from golden snapshot '%s' by %.2f%% pixels, see %s and %s",
+		golden, mismatched*100, actualPath, diffPath)
+}
+
+// compareFrames returns diff image, where differing pixels are red and the rest are faded expected ones,
+// along with the share of differing pixels. Frames of different sizes are entirely different.
+func compareFrames(expected, actual image.Image) (*image.RGBA, float64) {
+	var (
+		b    = expected.Bounds()
+		diff = image.NewRGBA(b)
+	)
+
+	if !b.Size().Eq(actual.Bounds().Size()) {
+		return diff, 1
+	}
+
+	var (
+		offset     = actual.Bounds().Min.Sub(b.Min)
+		mismatched = 0
+	)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			var (
+				e = color.RGBAModel.Convert(expected.At(x, y)).(color.RGBA)
+				a = color.RGBAModel.Convert(actual.At(x+offset.X, y+offset.Y)).(color.RGBA)
+			)
+
+			if channelsDiffer(e, a) {
+				diff.SetRGBA(x, y, color.RGBA{R: 0xFF, A: 0xFF})
+				mismatched++
+				continue
+			}
+
+			diff.SetRGBA(x, y, color.RGBA{
+				R: 0xFF - (0xFF-e.R)/4,
+				G: 0xFF - (0xFF-e.G)/4,
+				B: 0xFF - (0xFF-e.B)/4,
+				A: 0xFF,
+			})
+		}
+	}
+
+	return diff, float64(mismatched) / float64(b.Dx()*b.Dy())
+}
+
+func channelsDiffer(a, b color.RGBA) bool {
+	for _, d := range []int{
+		int(a.R) - int(b.R),
+		int(a.G) - int(b.G),
+		int(a.B) - int(b.B),
+		int(a.A) - int(b.A),
+	} {
+		if d > channelTolerance || d < -channelTolerance {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
@@ -2,12 +2,7 @@ package gui
 
 import (
 	"fmt"
-
-	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
-	"github.com/pkg/errors"
-	"github.com/timoth-y/chainmetric-iot/shared"
-	"golang.org/x/image/font/gofont/goregular"
+	"image"
 )
 
 var (
@@ -18,33 +13,86 @@ func SetBatteryLevel(level int) {
 	batteryLevel = level
 }
 
+// Battery is a Widget displaying battery level with icon, which is highlighted when the level is low.
+type Battery struct{}
+
+func (Battery) Measure(max image.Point) image.Point {
+	return Battery{}.layout().Measure(max)
+}
+
+func (Battery) Draw(r image.Rectangle) {
+	Battery{}.layout().Draw(r)
+}
+
+func (Battery) layout() Widget {
+	var accent = currentTheme.Foreground
+	if batteryLevel <= batteryLowLevel {
+		accent = currentTheme.Error
+	}
 
-func renderBatteryLevel() {
-	var(
-		icon = iconPath("battery")
-		iconImg, err = gg.LoadPNG(iconPath("battery"))
-		line = fmt.Sprintf("%d%%", batteryLevel)
+	return Row(
+		Text{Content: fmt.Sprintf("%d%%", batteryLevel), Size: 13, Color: accent},
+		Icon{Name: "battery", Color: accent},
 	)
+}
+
+// StatusBar is a Widget displaying Title along with the battery level on the right.
+type StatusBar struct {
+	Title string
+}
 
-	if err != nil {
-		shared.Logger.Error(errors.Wrapf(err, "failed to load from path '%s'", icon))
+func (s StatusBar) Measure(max image.Point) image.Point {
+	return s.layout().Measure(max)
+}
+
+func (s StatusBar) Draw(r image.Rectangle) {
+	s.layout().Draw(r)
+}
+
+func (s StatusBar) layout() Widget {
+	return Row(
+		Expanded(Text{Content: s.Title, Font: MediumFont, Size: 16}),
+		Battery{},
+	)
+}
+
+// PageIndicator is a Widget displaying dots at the bottom of the frame,
+// highlighting the position of the current screen. It takes no space if there are less than two screens.
+type PageIndicator struct{}
+
+func (PageIndicator) Measure(max image.Point) image.Point {
+	if _, count := screenPosition(); count < 2 {
+		return image.Point{}
+	}
+
+	return image.Pt(max.X, minInt(int(fontSize(8)), max.Y))
+}
+
+func (PageIndicator) Draw(r image.Rectangle) {
+	var current, count = screenPosition()
+
+	if count < 2 {
 		return
 	}
 
 	var (
-		ib = iconImg.Bounds()
-		tx, th = ctx.MeasureString(line)
+		radius = fontSize(2)
+		step   = radius * 4
+		x      = float64(r.Min.X + r.Dx() / 2) - step * float64(count - 1) / 2
+		y      = float64(r.Min.Y + r.Dy() / 2)
 	)
 
-	font, err := truetype.Parse(goregular.TTF)
-	if err != nil {
-		shared.Logger.Error(errors.Wrap(err, "failed to parse font"))
-	}
+	for i := 0; i < count; i++ {
+		ctx.DrawCircle(x + step * float64(i), y, radius)
 
-	ctx.SetFontFace(truetype.NewFace(font, &truetype.Options{
-		Size: 13,
-	}))
+		if i == current {
+			ctx.SetColor(currentTheme.Accent)
+			ctx.Fill()
+		} else {
+			ctx.SetColor(currentTheme.Foreground)
+			ctx.Stroke()
+		}
+	}
 
-	ctx.DrawString(line, float64(frameWidth - ib.Dx()) - tx - 2.5, th)
-	ctx.DrawImage(iconImg, int(frameWidth - ib.Dx()), 1)
+	ctx.SetColor(currentTheme.Foreground)
 }
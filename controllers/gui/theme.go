@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	core "github.com/timoth-y/chainmetric-iot/core/dev"
+	"github.com/wcharczuk/go-chart/drawing"
+	"periph.io/x/periph/devices/ssd1306/image1bit"
+)
+
+// theme defines set of colors used for frame rendering.
+type theme struct {
+	Background color.Color
+	Foreground color.Color
+	Accent     color.Color
+	Success    color.Color
+	Warning    color.Color
+	Error      color.Color
+}
+
+var (
+	// monochromeTheme is used for the displays capable of rendering only black and white pixels, like e-ink.
+	monochromeTheme = theme{
+		Background: color.White,
+		Foreground: color.Black,
+		Accent:     color.Black,
+		Success:    color.Black,
+		Warning:    color.Black,
+		Error:      color.Black,
+	}
+
+	// colorTheme is used for the color displays, like ST7789 LCD.
+	colorTheme = theme{
+		Background: color.RGBA{R: 0xFA, G: 0xFA, B: 0xFA, A: 0xFF},
+		Foreground: color.RGBA{R: 0x21, G: 0x21, B: 0x21, A: 0xFF},
+		Accent:     color.RGBA{R: 0x19, G: 0x76, B: 0xD2, A: 0xFF},
+		Success:    color.RGBA{R: 0x38, G: 0x8E, B: 0x3C, A: 0xFF},
+		Warning:    color.RGBA{R: 0xF5, G: 0x7C, B: 0x00, A: 0xFF},
+		Error:      color.RGBA{R: 0xD3, G: 0x2F, B: 0x2F, A: 0xFF},
+	}
+
+	currentTheme = monochromeTheme
+)
+
+// batteryLowLevel defines battery level percentage from which it is highlighted with error color.
+const batteryLowLevel = 20
+
+// selectTheme determines theme based on the color model of the display device.
+func selectTheme(d core.Display) theme {
+	switch d.ColorModel() {
+	case image1bit.BitModel, color.GrayModel, color.Gray16Model:
+		return monochromeTheme
+	default:
+		return colorTheme
+	}
+}
+
+// colored checks whether the current display device supports colors.
+func colored() bool {
+	return currentTheme != monochromeTheme
+}
+
+// tint paints all opaque pixels of the `img` with color `c`, preserving image alpha channel.
+// Icons assets are expected to be dark pixels on transparent background, so it's safe to use alpha as a mask.
+func tint(img image.Image, c color.Color) image.Image {
+	if !colored() {
+		return img
+	}
+
+	tinted := image.NewRGBA(img.Bounds())
+	draw.DrawMask(tinted, tinted.Bounds(), image.NewUniform(c), image.Point{}, img, img.Bounds().Min, draw.Over)
+
+	return tinted
+}
+
+// chartColor converts `c` to color type used for chart rendering.
+func chartColor(c color.Color) drawing.Color {
+	r, g, b, a := c.RGBA()
+	return drawing.Color{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}
+}
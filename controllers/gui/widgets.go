@@ -0,0 +1,339 @@
+package gui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/skip2/go-qrcode"
+	"github.com/wcharczuk/go-chart"
+	"golang.org/x/image/font"
+
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// Align defines horizontal alignment of the Text lines.
+type Align int
+
+const (
+	// AlignLeft aligns text lines to the left edge.
+	AlignLeft Align = iota
+	// AlignCenter aligns text lines to the center.
+	AlignCenter
+	// AlignRight aligns text lines to the right edge.
+	AlignRight
+)
+
+const (
+	defaultFontSize = 14
+	lineSpacing     = 3
+)
+
+// Text is a Widget displaying multiline text content.
+type Text struct {
+	Content string
+	// Font defines typeface of the text, RegularFont is used by default.
+	Font Font
+	// Size defines size of the font in points for the reference frame size, 14 is used by default.
+	Size float64
+	// Color defines color of the text, theme foreground color is used by default.
+	Color color.Color
+	Align Align
+}
+
+func (t Text) Measure(max image.Point) image.Point {
+	ctx.SetFontFace(t.face())
+
+	var (
+		lines = strings.Split(t.Content, "\n")
+		width float64
+	)
+
+	for _, line := range lines {
+		if lw, _ := ctx.MeasureString(line); lw > width {
+			width = lw
+		}
+	}
+
+	height := float64(len(lines))*ctx.FontHeight() + float64(len(lines)-1)*fontSize(lineSpacing)
+
+	return image.Pt(
+		minInt(int(math.Ceil(width)), max.X),
+		minInt(int(math.Ceil(height)), max.Y),
+	)
+}
+
+func (t Text) Draw(r image.Rectangle) {
+	ctx.SetFontFace(t.face())
+	ctx.SetColor(colorOrDefault(t.Color, currentTheme.Foreground))
+	defer ctx.SetColor(currentTheme.Foreground)
+
+	var (
+		lh = ctx.FontHeight()
+		y  = float64(r.Min.Y)
+	)
+
+	for _, line := range strings.Split(t.Content, "\n") {
+		// Lines which don't fit into the region are omitted:
+		if y+lh > float64(r.Max.Y)+1 {
+			break
+		}
+
+		var (
+			lw, _ = ctx.MeasureString(line)
+			x     = float64(r.Min.X)
+		)
+
+		switch t.Align {
+		case AlignCenter:
+			x += (float64(r.Dx()) - lw) / 2
+		case AlignRight:
+			x += float64(r.Dx()) - lw
+		}
+
+		ctx.DrawString(line, x, y+lh)
+		y += lh + fontSize(lineSpacing)
+	}
+}
+
+func (t Text) face() font.Face {
+	var (
+		f    = t.Font
+		size = t.Size
+	)
+
+	if len(f) == 0 {
+		f = RegularFont
+	}
+
+	if size == 0 {
+		size = defaultFontSize
+	}
+
+	return fontFace(f, fontSize(size))
+}
+
+// Icon is a Widget displaying image asset by its Name.
+type Icon struct {
+	Name string
+	// Color defines color the icon is tinted with on color displays, theme foreground color is used by default.
+	Color color.Color
+}
+
+func (i Icon) Measure(max image.Point) image.Point {
+	img, err := loadIcon(i.Name)
+	if err != nil {
+		return image.Point{}
+	}
+
+	return image.Pt(minInt(img.Bounds().Dx(), max.X), minInt(img.Bounds().Dy(), max.Y))
+}
+
+func (i Icon) Draw(r image.Rectangle) {
+	img, err := loadIcon(i.Name)
+	if err != nil {
+		shared.Logger.Error(err)
+		return
+	}
+
+	var offset = r.Size().Sub(img.Bounds().Size()).Div(2)
+
+	ctx.DrawImage(tint(img, colorOrDefault(i.Color, currentTheme.Foreground)), r.Min.X+offset.X, r.Min.Y+offset.Y)
+}
+
+// Chart is a Widget displaying line chart of the Values, which takes all available space.
+type Chart struct {
+	Values []float64
+}
+
+func (c Chart) Measure(max image.Point) image.Point {
+	return max
+}
+
+func (c Chart) Draw(r image.Rectangle) {
+	// Chart renderer fails on too small canvas:
+	if r.Dx() < 10 || r.Dy() < 10 || len(c.Values) == 0 {
+		return
+	}
+
+	xValues := make([]float64, len(c.Values))
+	for i := range c.Values {
+		xValues[i] = float64(i) + 1
+	}
+
+	var padding = chart.DefaultBackgroundPadding
+	if compactLayout() {
+		padding = chart.Box{Top: 2, Left: 2, Right: 2, Bottom: 2}
+	}
+
+	chart.DefaultFillColor = chartColor(currentTheme.Foreground)
+	graph := chart.Chart{
+		Background: chart.Style{
+			FillColor: chartColor(currentTheme.Background),
+			Padding:   padding,
+		},
+		Canvas: chart.Style{
+			FillColor: chartColor(currentTheme.Background),
+		},
+		XAxis: chart.XAxis{
+			Style: chart.Style{
+				FontColor: chartColor(currentTheme.Foreground),
+			},
+		},
+		YAxis: chart.YAxis{
+			Style: chart.Style{
+				FontColor: chartColor(currentTheme.Foreground),
+			},
+		},
+		Series: []chart.Series{
+			chart.ContinuousSeries{
+				XValues: xValues,
+				YValues: c.Values,
+				Style: chart.Style{
+					Show:        true,
+					StrokeWidth: math.Max(1, 3*layoutScale()),
+					DotColor:    chartColor(currentTheme.Accent),
+					StrokeColor: chartColor(currentTheme.Accent),
+				},
+			},
+		},
+		Height: r.Dy(),
+		Width:  r.Dx(),
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	if err := graph.Render(chart.PNG, buffer); err != nil {
+		shared.Logger.Error(errors.Wrap(err, "failed to render chart"))
+		return
+	}
+
+	if img, _, err := image.Decode(buffer); err != nil {
+		shared.Logger.Error(errors.Wrap(err, "failed to decode chart image"))
+	} else {
+		ctx.DrawImage(img, r.Min.X, r.Min.Y)
+	}
+}
+
+// ProgressBar is a Widget displaying horizontal bar filled proportionally to the Value in range from 0 to 1.
+type ProgressBar struct {
+	Value float64
+	// Color defines color of the filled part, theme accent color is used by default.
+	Color color.Color
+}
+
+func (p ProgressBar) Measure(max image.Point) image.Point {
+	return image.Pt(max.X, minInt(int(fontSize(8)), max.Y))
+}
+
+func (p ProgressBar) Draw(r image.Rectangle) {
+	var (
+		value = math.Max(0, math.Min(1, p.Value))
+		x, y  = float64(r.Min.X), float64(r.Min.Y)
+		w, h  = float64(r.Dx()), float64(r.Dy())
+	)
+
+	ctx.SetColor(colorOrDefault(p.Color, currentTheme.Accent))
+	ctx.DrawRectangle(x, y, w*value, h)
+	ctx.Fill()
+
+	ctx.SetColor(currentTheme.Foreground)
+	ctx.SetLineWidth(1)
+	ctx.DrawRectangle(x+0.5, y+0.5, w-1, h-1)
+	ctx.Stroke()
+}
+
+// QRCode is a Widget displaying QR code of the Data, which takes the largest square fitting available space.
+type QRCode struct {
+	Data string
+}
+
+func (q QRCode) Measure(max image.Point) image.Point {
+	side := minInt(max.X, max.Y)
+	return image.Pt(side, side)
+}
+
+func (q QRCode) Draw(r image.Rectangle) {
+	img, err := qrImage(q.Data, minInt(r.Dx(), r.Dy()))
+	if err != nil {
+		shared.Logger.Error(err)
+		Text{Content: "QR code is too large", Align: AlignCenter}.Draw(r)
+		return
+	}
+
+	var offset = r.Size().Sub(img.Bounds().Size()).Div(2)
+
+	ctx.DrawImage(img, r.Min.X+offset.X, r.Min.Y+offset.Y)
+}
+
+// qrImage creates QR code image of the `data` with `size` pixels side.
+// On small displays QR code is rendered without quiet zone border and with lower error recovery level when needed.
+func qrImage(data string, size int) (image.Image, error) {
+	for _, level := range []qrcode.RecoveryLevel{qrcode.Medium, qrcode.Low} {
+		qr, err := qrcode.New(data, level)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create QR code image")
+		}
+
+		qr.DisableBorder = compactLayout()
+
+		// Each module requires at least one pixel, otherwise QR code will be larger than frame:
+		if len(qr.Bitmap()) <= size {
+			return qr.Image(size), nil
+		}
+	}
+
+	return nil, errors.Errorf("QR code of %d bytes does not fit into %dx%d pixels", len(data), size, size)
+}
+
+// List is a Widget displaying Lines one under another, which takes all available space.
+// Lines which don't fit are replaced with the count of the remaining ones.
+type List struct {
+	Lines []string
+}
+
+func (l List) Measure(max image.Point) image.Point {
+	return max
+}
+
+func (l List) Draw(r image.Rectangle) {
+	var (
+		lines = l.Lines
+		y     = r.Min.Y
+	)
+
+	if len(lines) == 0 {
+		lines = []string{"None"}
+	}
+
+	for i, line := range lines {
+		var (
+			text = Text{Content: line, Size: 13}
+			lh   = text.Measure(r.Size()).Y + int(fontSize(lineSpacing))
+		)
+
+		// Last line which fits is replaced with remaining lines count, if there are more of them:
+		if i < len(lines)-1 && y+2*lh > r.Max.Y {
+			text.Content = fmt.Sprintf("... and %d more", len(lines)-i)
+		}
+
+		text.Draw(image.Rect(r.Min.X, y, r.Max.X, y+lh))
+
+		if text.Content != line {
+			break
+		}
+
+		y += lh
+	}
+}
+
+func colorOrDefault(c, def color.Color) color.Color {
+	if c == nil {
+		return def
+	}
+
+	return c
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/timoth-y/chainmetric-core/utils"
+
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// PutCalibration stores encoded calibration results of the sensor instance with given `key`.
+func PutCalibration(key string, data []byte) error {
+	if shared.LevelDB == nil {
+		return errors.New("LevelDB is not available")
+	}
+
+	return shared.LevelDB.Put([]byte(utils.FormCompositeKey("calibration", key)), data, nil)
+}
+
+// GetCalibration retrieves encoded calibration results of the sensor instance with given `key`.
+// Returns false when no calibration was persisted yet.
+func GetCalibration(key string) ([]byte, bool, error) {
+	if shared.LevelDB == nil {
+		return nil, false, nil
+	}
+
+	data, err := shared.LevelDB.Get([]byte(utils.FormCompositeKey("calibration", key)), nil)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
@@ -109,3 +109,41 @@ func IterateOverCachedReadings(ctx context.Context, fn ReadingsCacheIteratorFunc
 		}
 	}
 }
+
+// CountCachedReadings returns number of models.MetricReadings records stored in local cache DB.
+func CountCachedReadings() (int, error) {
+	var (
+		prefix = []byte(utils.FormCompositeKey("reading"))
+		iter = shared.LevelDB.NewIterator(util.BytesPrefix(prefix), nil)
+		count int
+	)
+
+	defer iter.Release()
+
+	for iter.Next() {
+		count++
+	}
+
+	return count, iter.Error()
+}
+
+// FlushCachedReadings removes all models.MetricReadings records from local cache DB and returns their number.
+func FlushCachedReadings() (int, error) {
+	var (
+		prefix = []byte(utils.FormCompositeKey("reading"))
+		iter = shared.LevelDB.NewIterator(util.BytesPrefix(prefix), nil)
+		batch = new(leveldb.Batch)
+	)
+
+	for iter.Next() {
+		batch.Delete(append([]byte(nil), iter.Key()...))
+	}
+
+	iter.Release()
+
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+
+	return batch.Len(), shared.LevelDB.Write(batch, nil)
+}
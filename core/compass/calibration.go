@@ -0,0 +1,155 @@
+package compass
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/model"
+)
+
+// MinCalibrationSamples defines minimal number of samples required to fit the Calibration.
+const MinCalibrationSamples = 50
+
+// Calibration defines magnetometer hard-iron and soft-iron distortions correction.
+type Calibration struct {
+	// Offset is a hard-iron offset, which is subtracted from the raw readings.
+	Offset model.Vector `json:"offset"`
+	// SoftIron is a matrix transforming readings ellipsoid into the sphere.
+	SoftIron Matrix `json:"soft_iron"`
+}
+
+// Identity returns Calibration, which leaves readings as is.
+func Identity() Calibration {
+	return Calibration{
+		SoftIron: IdentityMatrix(),
+	}
+}
+
+// Apply corrects raw magnetometer reading `v` with the Calibration.
+func (c Calibration) Apply(v model.Vector) model.Vector {
+	r := c.SoftIron.Mul([3]float64{
+		v.X - c.Offset.X,
+		v.Y - c.Offset.Y,
+		v.Z - c.Offset.Z,
+	})
+
+	return model.Vector{X: r[0], Y: r[1], Z: r[2]}
+}
+
+// Fit determines Calibration by fitting ellipsoid to the raw magnetometer `samples`,
+// which are expected to be collected while the device is rotated in all directions.
+// The resulting Calibration maps readings onto the sphere with the radius of the fitted ellipsoid geometric mean.
+func Fit(samples []model.Vector) (Calibration, error) {
+	if len(samples) < MinCalibrationSamples {
+		return Calibration{}, errors.Errorf("at least %d samples are required, got %d",
+			MinCalibrationSamples, len(samples))
+	}
+
+	// Samples are normalized to keep the system well conditioned:
+	var scale float64
+	for _, s := range samples {
+		scale = math.Max(scale, math.Max(math.Abs(s.X), math.Max(math.Abs(s.Y), math.Abs(s.Z))))
+	}
+
+	if scale == 0 {
+		return Calibration{}, errors.New("samples contain no signal")
+	}
+
+	// Least squares fit of the general quadric surface:
+	// ax² + by² + cz² + 2dxy + 2exz + 2fyz + 2gx + 2hy + 2iz = 1
+	var (
+		ata = make([][]float64, 9)
+		atb = make([]float64, 9)
+	)
+
+	for i := range ata {
+		ata[i] = make([]float64, 9)
+	}
+
+	for _, s := range samples {
+		x, y, z := s.X/scale, s.Y/scale, s.Z/scale
+		row := []float64{x * x, y * y, z * z, 2 * x * y, 2 * x * z, 2 * y * z, 2 * x, 2 * y, 2 * z}
+
+		for i := range row {
+			for j := range row {
+				ata[i][j] += row[i] * row[j]
+			}
+			atb[i] += row[i]
+		}
+	}
+
+	p, err := solveLinear(ata, atb)
+	if err != nil {
+		return Calibration{}, errors.Wrap(err, "samples do not cover enough orientations")
+	}
+
+	var (
+		m = Matrix{
+			{p[0], p[3], p[4]},
+			{p[3], p[1], p[5]},
+			{p[4], p[5], p[2]},
+		}
+		linear = [3]float64{p[6], p[7], p[8]}
+	)
+
+	mi, err := m.Inverse()
+	if err != nil {
+		return Calibration{}, errors.Wrap(err, "samples do not form an ellipsoid")
+	}
+
+	var (
+		center = mi.Mul(linear)
+		k      = 1.0
+	)
+
+	for i := range center {
+		center[i] = -center[i]
+	}
+
+	mc := m.Mul(center)
+	for i := range center {
+		k += center[i] * mc[i]
+	}
+
+	if k <= 0 {
+		return Calibration{}, errors.New("samples do not form an ellipsoid")
+	}
+
+	values, vectors := eigenSymmetric(m)
+
+	var (
+		radius = 1.0
+		root   Matrix
+	)
+
+	for i := range values {
+		if values[i] <= 0 {
+			return Calibration{}, errors.New("samples do not form an ellipsoid")
+		}
+
+		values[i] /= k
+		radius *= values[i]
+	}
+
+	radius = math.Pow(radius, -1.0/6)
+
+	// Soft-iron matrix is a square root of the normalized quadric matrix, scaled to the mean radius:
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for l := 0; l < 3; l++ {
+				root[i][j] += vectors[i][l] * math.Sqrt(values[l]) * vectors[j][l]
+			}
+			root[i][j] *= radius
+		}
+	}
+
+	return Calibration{
+		Offset: model.Vector{
+			X: center[0] * scale,
+			Y: center[1] * scale,
+			Z: center[2] * scale,
+		},
+		SoftIron: root,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package compass
+
+import (
+	"math"
+
+	"github.com/timoth-y/chainmetric-iot/model"
+)
+
+// Heading returns tilt-compensated compass heading in degrees (0-360, clockwise from magnetic north)
+// based on calibrated magnetometer reading `mag` and accelerometer reading `acc`, which defines the gravity direction.
+func Heading(mag, acc model.Vector) float64 {
+	var (
+		roll  = math.Atan2(acc.Y, acc.Z)
+		pitch = math.Atan2(-acc.X, acc.Y*math.Sin(roll)+acc.Z*math.Cos(roll))
+	)
+
+	// Projection of the magnetic field vector onto the horizontal plane:
+	var (
+		bx = mag.X*math.Cos(pitch) + mag.Y*math.Sin(pitch)*math.Sin(roll) + mag.Z*math.Sin(pitch)*math.Cos(roll)
+		by = mag.Y*math.Cos(roll) - mag.Z*math.Sin(roll)
+	)
+
+	return math.Mod(math.Atan2(-by, bx)*180/math.Pi+360, 360)
+}
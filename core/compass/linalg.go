@@ -0,0 +1,150 @@
+package compass
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Matrix defines 3x3 matrix used for soft-iron correction.
+type Matrix [3][3]float64
+
+// IdentityMatrix returns 3x3 identity Matrix.
+func IdentityMatrix() Matrix {
+	return Matrix{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+}
+
+// Mul returns product of the Matrix and the `v` column vector.
+func (m Matrix) Mul(v [3]float64) (r [3]float64) {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i] += m[i][j] * v[j]
+		}
+	}
+
+	return
+}
+
+// Det returns determinant of the Matrix.
+func (m Matrix) Det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// Inverse returns inverse of the Matrix, or error if it is singular.
+func (m Matrix) Inverse() (Matrix, error) {
+	det := m.Det()
+	if math.Abs(det) < 1e-12 {
+		return Matrix{}, errors.New("matrix is singular")
+	}
+
+	return Matrix{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}, nil
+}
+
+// eigenSymmetric computes eigenvalues and eigenvectors (as columns) of the symmetric Matrix with Jacobi rotations.
+func eigenSymmetric(m Matrix) (values [3]float64, vectors Matrix) {
+	var (
+		a = m
+		v = IdentityMatrix()
+	)
+
+	for sweep := 0; sweep < 50; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if off < 1e-20 {
+			break
+		}
+
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if math.Abs(a[p][q]) < 1e-15 {
+					continue
+				}
+
+				var (
+					theta = (a[q][q] - a[p][p]) / (2 * a[p][q])
+					t     = math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+					c     = 1 / math.Sqrt(t*t+1)
+					s     = t * c
+				)
+
+				for k := 0; k < 3; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+
+				for k := 0; k < 3; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+
+				for k := 0; k < 3; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	return [3]float64{a[0][0], a[1][1], a[2][2]}, v
+}
+
+// solveLinear solves `a`·x = `b` system with Gaussian elimination and partial pivoting.
+func solveLinear(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, errors.New("system is degenerate")
+		}
+
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+			b[row] -= f * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+
+	return x, nil
+}
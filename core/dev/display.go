@@ -2,6 +2,7 @@ package dev
 
 import (
 	"image"
+	"image/color"
 )
 
 // Display defines base methods for controlling display device.
@@ -24,8 +25,18 @@ type Display interface {
 	Refresh() error
 	// Bounds returns Display dimensions.
 	Bounds() image.Rectangle
+	// ColorModel returns color model supported by Display.
+	ColorModel() color.Model
 	// Active checks whether the Display device is connected and active.
 	Active() bool
 	// Close closes connection to Display device and clears allocated resources.
 	Close() error
 }
+
+// PartialDisplay defines Display device capable of updating only part of the frame,
+// which is faster and less wearing than the full refresh.
+type PartialDisplay interface {
+	Display
+	// DrawPartial sends `r` region of the image to PartialDisplay and updates only that region.
+	DrawPartial(src image.Image, r image.Rectangle) error
+}
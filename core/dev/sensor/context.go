@@ -12,15 +12,31 @@ type Context struct {
 	context.Context
 	SensorID string
 	Pipe     ReadingsPipe
+	priority func(metric models.Metric) Priority
 }
 
 // NewReaderContext constructs new Context instance based on given `parent` context for the given sensor.Sensor.
 func NewReaderContext(parent context.Context, sensor Sensor) *Context {
-	return &Context{
+	ctx := &Context{
 		Context: parent,
 		SensorID: sensor.ID(),
 		Pipe: make(ReadingsPipe),
 	}
+
+	if p, ok := sensor.(Prioritized); ok {
+		ctx.priority = p.Priority
+	}
+
+	return ctx
+}
+
+// PriorityOf returns readings Priority of the given `metric` for the sensor.Sensor.
+func (c *Context) PriorityOf(metric models.Metric) Priority {
+	if c.priority == nil {
+		return PriorityDefault
+	}
+
+	return c.priority(metric)
 }
 
 // WriterFor returns MetricWriter for a given models.Metric.
@@ -0,0 +1,57 @@
+package sensor
+
+import (
+	"sync"
+)
+
+var (
+	leases     = make(map[Sensor]int)
+	leasesLock = &sync.Mutex{}
+)
+
+// Acquire leases Sensor for use, initializing it if it isn't active yet.
+// Leased Sensor won't be closed with CloseIdle, e.g. on standby, until every lease is returned with Release.
+func Acquire(sn Sensor) error {
+	leasesLock.Lock()
+	defer leasesLock.Unlock()
+
+	if !sn.Active() {
+		if err := sn.Init(); err != nil {
+			return err
+		}
+	}
+
+	leases[sn]++
+
+	return nil
+}
+
+// Release returns Sensor lease taken with Acquire. Sensor stays active until it is closed with CloseIdle.
+func Release(sn Sensor) {
+	leasesLock.Lock()
+	defer leasesLock.Unlock()
+
+	if leases[sn] > 1 {
+		leases[sn]--
+		return
+	}
+
+	delete(leases, sn)
+}
+
+// CloseIdle closes active Sensor, unless it is leased.
+// Returns false when Sensor is kept active, since it is still being used.
+func CloseIdle(sn Sensor) (bool, error) {
+	leasesLock.Lock()
+	defer leasesLock.Unlock()
+
+	if leases[sn] > 0 {
+		return false, nil
+	}
+
+	if !sn.Active() {
+		return true, nil
+	}
+
+	return true, sn.Close()
+}
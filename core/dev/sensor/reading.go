@@ -8,7 +8,19 @@ import (
 type ReadingResult struct {
 	Source string
 	Value float64
+	Priority Priority
 }
 
+// Priority defines precedence of the ReadingResult when multiply sensors provide the same models.Metric.
+type Priority int
+
+const (
+	// PriorityDefault is a Priority of readings from sensors without any specific precedence.
+	PriorityDefault Priority = 0
+	// PriorityHigh is a Priority of readings from sensors, which measure the models.Metric directly,
+	// rather than estimating it from other values.
+	PriorityHigh Priority = 10
+)
+
 // ReadingsPipe maps where to dump sensor.Sensor ReadingResult for concrete models.Metric.
 type ReadingsPipe map[models.Metric] chan ReadingResult
@@ -2,6 +2,7 @@ package sensor
 
 import (
 	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/chainmetric-core/models/metrics"
 )
 
 // SensorsRegister represents pool of the multiply sensor.Sensor devices.
@@ -72,3 +73,52 @@ func (sr SensorsRegister) Exists(id string) bool {
 	_, is := sr[id]
 	return is
 }
+
+// Accelerometers returns all AxesReader devices presented in SensorsRegister, which are reading acceleration.
+func (sr SensorsRegister) Accelerometers() []AxesReader {
+	var l []AxesReader
+
+	for id := range sr {
+		if ar, ok := sr[id].(AxesReader); ok && supportsMetric(ar, metrics.Acceleration) {
+			l = append(l, ar)
+		}
+	}
+
+	return l
+}
+
+// Calibrators returns all Calibrator devices presented in SensorsRegister.
+func (sr SensorsRegister) Calibrators() []Calibrator {
+	var l []Calibrator
+
+	for id := range sr {
+		if c, ok := sr[id].(Calibrator); ok {
+			l = append(l, c)
+		}
+	}
+
+	return l
+}
+
+// CO2Recalibrators returns all CO2Recalibrator devices presented in SensorsRegister.
+func (sr SensorsRegister) CO2Recalibrators() []CO2Recalibrator {
+	var l []CO2Recalibrator
+
+	for id := range sr {
+		if c, ok := sr[id].(CO2Recalibrator); ok {
+			l = append(l, c)
+		}
+	}
+
+	return l
+}
+
+func supportsMetric(s Sensor, metric models.Metric) bool {
+	for _, m := range s.Metrics() {
+		if m == metric {
+			return true
+		}
+	}
+
+	return false
+}
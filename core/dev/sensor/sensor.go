@@ -1,7 +1,11 @@
 package sensor
 
 import (
+	"context"
+	"time"
+
 	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/chainmetric-iot/model"
 )
 
 // Sensor defines base methods for controlling sensor device.
@@ -22,3 +26,45 @@ type Sensor interface {
 	Close() error
 }
 
+
+// AxesReader defines Sensor capable of reading three-axis vector data, e.g. accelerometer or magnetometer.
+type AxesReader interface {
+	Sensor
+	// ReadAxes retrieves three-axis data from Sensor device.
+	ReadAxes() (model.Vector, error)
+}
+
+// PowerMeter defines Sensor capable of measuring voltage, current and power of the power rail.
+type PowerMeter interface {
+	Sensor
+	// ReadPower retrieves power rail measurement from Sensor device.
+	ReadPower() (model.PowerReading, error)
+}
+
+// Calibrator defines Sensor, which requires calibration routine to be performed in place.
+// Calibration results are persisted by the caller, so that drivers stay independent of the storage.
+type Calibrator interface {
+	Sensor
+	// CalibrationKey returns key of the Sensor instance, which calibration results are persisted by.
+	CalibrationKey() string
+	// Calibrate performs calibration routine during given `duration`, applies its results and returns them encoded.
+	Calibrate(ctx context.Context, duration time.Duration) ([]byte, error)
+	// RestoreCalibration applies encoded calibration results returned by Calibrate before.
+	RestoreCalibration(data []byte) error
+}
+
+// CO2Recalibrator defines CO2 Sensor, which supports forced recalibration to the known concentration of the reference environment.
+type CO2Recalibrator interface {
+	Sensor
+	// RecalibrationWarmUp returns minimal duration of the Sensor operation in the reference environment before recalibration.
+	RecalibrationWarmUp() time.Duration
+	// Recalibrate performs forced recalibration, assuming the Sensor measures `referencePPM` CO2 concentration.
+	Recalibrate(referencePPM int) error
+}
+
+// Prioritized defines Sensor, which readings of certain models.Metric should take precedence
+// over ones from other sensors providing the same models.Metric.
+type Prioritized interface {
+	// Priority returns readings Priority of the given `metric`.
+	Priority(metric models.Metric) Priority
+}
@@ -41,6 +41,7 @@ func (w *MetricWriter) Write(v interface{}) {
 		ch <- ReadingResult{
 			Source: w.ctx.SensorID,
 			Value:  value,
+			Priority: w.ctx.PriorityOf(w.metric),
 		}
 	}
 }
@@ -0,0 +1,221 @@
+package motion
+
+import (
+	"math"
+	"time"
+
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+)
+
+// Detector defines stateful analyzer of accelerometer readings sequence,
+// which keeps orientation baseline and detects tilt, shock, and free-fall Event's.
+type Detector struct {
+	sensorID string
+	config   config.MotionConfig
+
+	baseline    *model.Vector
+	baselineSum model.Vector
+	baselineN   int
+	tilted      bool
+
+	shockStart time.Time
+	shockPeak  model.Vector
+	fallStart  time.Time
+	fallVector model.Vector
+}
+
+// NewDetector constructs new Detector instance for the sensor with given `sensorID`.
+func NewDetector(sensorID string, config config.MotionConfig) *Detector {
+	return &Detector{
+		sensorID: sensorID,
+		config:   config,
+	}
+}
+
+// Baseline returns current orientation baseline vector, if it is already established.
+func (d *Detector) Baseline() (model.Vector, bool) {
+	if d.baseline == nil {
+		return model.Vector{}, false
+	}
+
+	return *d.baseline, true
+}
+
+// ResetBaseline discards current orientation baseline, so it would be established again from the next readings.
+func (d *Detector) ResetBaseline() {
+	d.baseline = nil
+	d.baselineSum = model.Vector{}
+	d.baselineN = 0
+	d.tilted = false
+}
+
+// Process analyzes single accelerometer reading `v` (in G) taken at time `t` and returns detected Event's.
+func (d *Detector) Process(v model.Vector, t time.Time) (events []Event) {
+	var (
+		magnitude = Magnitude(v)
+	)
+
+	if e, ok := d.detectFreeFall(v, magnitude, t); ok {
+		events = append(events, e)
+	}
+
+	if e, ok := d.detectShock(v, magnitude, t); ok {
+		events = append(events, e)
+	}
+
+	if e, ok := d.detectTilt(v, magnitude, t); ok {
+		events = append(events, e)
+	}
+
+	return
+}
+
+func (d *Detector) detectFreeFall(v model.Vector, magnitude float64, t time.Time) (Event, bool) {
+	if magnitude < d.config.FreeFallThreshold {
+		if d.fallStart.IsZero() {
+			d.fallStart = t
+			d.fallVector = v
+		}
+
+		return Event{}, false
+	}
+
+	if d.fallStart.IsZero() {
+		return Event{}, false
+	}
+
+	var (
+		start    = d.fallStart
+		duration = t.Sub(start)
+	)
+
+	d.fallStart = time.Time{}
+
+	if duration < d.config.FreeFallMinDuration {
+		return Event{}, false
+	}
+
+	return Event{
+		Type:      FreeFall,
+		SensorID:  d.sensorID,
+		Timestamp: start,
+		Duration:  duration,
+		Vector:    d.fallVector,
+		Value:     duration.Seconds(),
+	}, true
+}
+
+func (d *Detector) detectShock(v model.Vector, magnitude float64, t time.Time) (Event, bool) {
+	if magnitude >= d.config.ShockThreshold {
+		if d.shockStart.IsZero() {
+			d.shockStart = t
+			d.shockPeak = v
+		} else if magnitude > Magnitude(d.shockPeak) {
+			d.shockPeak = v
+		}
+
+		return Event{}, false
+	}
+
+	if d.shockStart.IsZero() {
+		return Event{}, false
+	}
+
+	var (
+		start    = d.shockStart
+		duration = t.Sub(start)
+	)
+
+	d.shockStart = time.Time{}
+
+	if duration < d.config.ShockMinDuration {
+		return Event{}, false
+	}
+
+	return Event{
+		Type:      Shock,
+		SensorID:  d.sensorID,
+		Timestamp: start,
+		Duration:  duration,
+		Vector:    d.shockPeak,
+		Value:     Magnitude(d.shockPeak),
+	}, true
+}
+
+func (d *Detector) detectTilt(v model.Vector, magnitude float64, t time.Time) (Event, bool) {
+	// Orientation is only reliable while the device is at rest, so that only gravity is measured:
+	if math.Abs(magnitude-1) > restTolerance {
+		return Event{}, false
+	}
+
+	if d.baseline == nil {
+		d.baselineSum = model.Vector{
+			X: d.baselineSum.X + v.X,
+			Y: d.baselineSum.Y + v.Y,
+			Z: d.baselineSum.Z + v.Z,
+		}
+		d.baselineN++
+
+		if d.baselineN >= d.config.BaselineSamples {
+			d.baseline = &model.Vector{
+				X: d.baselineSum.X / float64(d.baselineN),
+				Y: d.baselineSum.Y / float64(d.baselineN),
+				Z: d.baselineSum.Z / float64(d.baselineN),
+			}
+		}
+
+		return Event{}, false
+	}
+
+	angle := Angle(*d.baseline, v)
+
+	if d.tilted {
+		// Hysteresis prevents flapping of events around the threshold:
+		if angle < d.config.TiltThreshold*tiltHysteresis {
+			d.tilted = false
+		}
+
+		return Event{}, false
+	}
+
+	if angle < d.config.TiltThreshold {
+		return Event{}, false
+	}
+
+	d.tilted = true
+
+	return Event{
+		Type:      Tilt,
+		SensorID:  d.sensorID,
+		Timestamp: t,
+		Vector:    v,
+		Value:     angle,
+	}, true
+}
+
+const (
+	restTolerance  = 0.15
+	tiltHysteresis = 0.8
+)
+
+// Magnitude returns length of the `v` vector.
+func Magnitude(v model.Vector) float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// Angle returns angle between `a` and `b` vectors in degrees.
+func Angle(a, b model.Vector) float64 {
+	var (
+		ma = Magnitude(a)
+		mb = Magnitude(b)
+	)
+
+	if ma == 0 || mb == 0 {
+		return 0
+	}
+
+	cos := (a.X*b.X + a.Y*b.Y + a.Z*b.Z) / (ma * mb)
+
+	return math.Acos(math.Max(-1, math.Min(1, cos))) * 180 / math.Pi
+}
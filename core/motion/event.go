@@ -0,0 +1,47 @@
+package motion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/timoth-y/chainmetric-iot/model"
+)
+
+// EventType defines type of the detected motion Event.
+type EventType string
+
+const (
+	// Tilt defines EventType of orientation change beyond threshold relative to the baseline.
+	Tilt EventType = "tilt"
+	// Shock defines EventType of acceleration exceeding threshold for a certain duration.
+	Shock EventType = "shock"
+	// FreeFall defines EventType of near-zero acceleration for a certain duration.
+	FreeFall EventType = "free_fall"
+)
+
+// Event defines structure of the motion event detected from accelerometer readings.
+type Event struct {
+	Type      EventType
+	SensorID  string
+	Timestamp time.Time
+	Duration  time.Duration
+	// Vector contains acceleration axes (in G) at the most significant moment of the Event.
+	Vector model.Vector
+	// Value is a Event magnitude: peak acceleration in G for Shock, angle in degrees for Tilt,
+	// and fall duration in seconds for FreeFall.
+	Value float64
+}
+
+// Describe returns human-readable description of the Event.
+func (e Event) Describe() string {
+	switch e.Type {
+	case Tilt:
+		return fmt.Sprintf("package was tilted by %.0f°", e.Value)
+	case Shock:
+		return fmt.Sprintf("package was hit with %.1fg shock", e.Value)
+	case FreeFall:
+		return fmt.Sprintf("package was dropped (%.0fms of free fall)", float64(e.Duration.Milliseconds()))
+	default:
+		return string(e.Type)
+	}
+}
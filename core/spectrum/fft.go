@@ -0,0 +1,63 @@
+package spectrum
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// FFT computes discrete Fourier transform of the `x` sequence with iterative radix-2 Cooley-Tukey algorithm.
+// Sequence is zero-padded up to the nearest power of two, so the result length may exceed the length of `x`.
+func FFT(x []complex128) []complex128 {
+	var (
+		n   = nextPowerOfTwo(len(x))
+		out = make([]complex128, n)
+	)
+
+	copy(out, x)
+
+	if n < 2 {
+		return out
+	}
+
+	// Bit-reversal permutation:
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+
+		if i < j {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+
+	// Butterfly passes:
+	for size := 2; size <= n; size <<= 1 {
+		var (
+			half = size / 2
+			step = cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		)
+
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < half; k++ {
+				even, odd := out[start+k], out[start+k+half]*w
+				out[start+k] = even + odd
+				out[start+k+half] = even - odd
+				w *= step
+			}
+		}
+	}
+
+	return out
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+
+	return p
+}
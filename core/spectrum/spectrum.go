@@ -0,0 +1,165 @@
+package spectrum
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Spectrum defines single-sided power spectrum of the sampled signal.
+type Spectrum struct {
+	// Power contains mean square value contributed by each frequency bin.
+	Power []float64
+	// Resolution is a frequency width of a single bin in Hz.
+	Resolution float64
+}
+
+// Analyze computes power Spectrum of the `samples` sequence taken with given `rate` (in Hz),
+// applying `window` function beforehand. Signal DC offset is removed prior to transformation.
+func Analyze(samples []float64, rate float64, window Window) Spectrum {
+	if len(samples) == 0 || rate <= 0 {
+		return Spectrum{}
+	}
+
+	if window == nil {
+		window = Hann
+	}
+
+	var (
+		mean   = Mean(samples)
+		coeffs = window(len(samples))
+		input  = make([]complex128, len(samples))
+		energy float64
+	)
+
+	for i := range samples {
+		input[i] = complex((samples[i]-mean)*coeffs[i], 0)
+		energy += coeffs[i] * coeffs[i]
+	}
+
+	var (
+		output = FFT(input)
+		n      = len(output)
+		power  = make([]float64, n/2+1)
+		norm   = float64(n) * energy
+	)
+
+	for k := range power {
+		p := math.Pow(cmplx.Abs(output[k]), 2) / norm
+
+		// Except for DC and Nyquist bins, energy of the negative frequencies is folded into positive ones:
+		if k != 0 && k != n/2 {
+			p *= 2
+		}
+
+		power[k] = p
+	}
+
+	return Spectrum{
+		Power:      power,
+		Resolution: rate / float64(n),
+	}
+}
+
+// Frequency returns center frequency of the `k` bin.
+func (s Spectrum) Frequency(k int) float64 {
+	return float64(k) * s.Resolution
+}
+
+// DominantFrequency returns frequency of the bin with highest power, ignoring DC component.
+func (s Spectrum) DominantFrequency() float64 {
+	var (
+		peak  = 0
+		power = 0.0
+	)
+
+	for k := 1; k < len(s.Power); k++ {
+		if s.Power[k] > power {
+			peak, power = k, s.Power[k]
+		}
+	}
+
+	return s.Frequency(peak)
+}
+
+// BandRMS returns root mean square of the signal components in [`from`, `to`) Hz frequency band.
+func (s Spectrum) BandRMS(from, to float64) float64 {
+	var sum float64
+
+	for k := range s.Power {
+		if f := s.Frequency(k); f >= from && f < to {
+			sum += s.Power[k]
+		}
+	}
+
+	return math.Sqrt(sum)
+}
+
+// RMS returns root mean square of the whole signal AC component.
+func (s Spectrum) RMS() float64 {
+	var sum float64
+
+	for k := 1; k < len(s.Power); k++ {
+		sum += s.Power[k]
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Weighted produces new Spectrum by applying frequency `weighting` gain function on each bin.
+func (s Spectrum) Weighted(weighting func(f float64) float64) Spectrum {
+	power := make([]float64, len(s.Power))
+
+	for k := range s.Power {
+		g := weighting(s.Frequency(k))
+		power[k] = s.Power[k] * g * g
+	}
+
+	return Spectrum{
+		Power:      power,
+		Resolution: s.Resolution,
+	}
+}
+
+// Mean returns arithmetic mean of the `samples`.
+func Mean(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for i := range samples {
+		sum += samples[i]
+	}
+
+	return sum / float64(len(samples))
+}
+
+// Peak returns maximum absolute deviation of the `samples` from their mean.
+func Peak(samples []float64) float64 {
+	var (
+		mean = Mean(samples)
+		peak float64
+	)
+
+	for i := range samples {
+		if d := math.Abs(samples[i] - mean); d > peak {
+			peak = d
+		}
+	}
+
+	return peak
+}
+
+// RootMeanSquare returns root mean square of the `samples`.
+func RootMeanSquare(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for i := range samples {
+		sum += samples[i] * samples[i]
+	}
+
+	return math.Sqrt(sum / float64(len(samples)))
+}
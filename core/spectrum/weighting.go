@@ -0,0 +1,32 @@
+package spectrum
+
+import (
+	"math"
+)
+
+// AWeighting returns linear gain of the A-weighting curve (IEC 61672-1) for given frequency `f` in Hz.
+func AWeighting(f float64) float64 {
+	if f <= 0 {
+		return 0
+	}
+
+	var (
+		f2  = f * f
+		num = math.Pow(12194, 2) * f2 * f2
+		den = (f2 + math.Pow(20.6, 2)) *
+			math.Sqrt((f2+math.Pow(107.7, 2))*(f2+math.Pow(737.9, 2))) *
+			(f2 + math.Pow(12194, 2))
+	)
+
+	// Normalization so that the gain at 1 kHz is 0 dB (+2.00 dB offset):
+	return num / den * math.Pow(10, 2.0/20)
+}
+
+// Decibels converts linear `ratio` of amplitudes into decibels.
+func Decibels(ratio float64) float64 {
+	if ratio <= 0 {
+		return math.Inf(-1)
+	}
+
+	return 20 * math.Log10(ratio)
+}
@@ -0,0 +1,61 @@
+package spectrum
+
+import (
+	"math"
+	"strings"
+)
+
+// Window defines windowing function producing `n` coefficients to be applied on samples before transformation.
+type Window func(n int) []float64
+
+// Rectangular is a Window that leaves samples as is.
+func Rectangular(n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1
+	}
+
+	return w
+}
+
+// Hann is a Window with raised cosine shape, which is a reasonable default for vibration and acoustic signals.
+func Hann(n int) []float64 {
+	w := make([]float64, n)
+	if n == 1 {
+		w[0] = 1
+		return w
+	}
+
+	for i := range w {
+		w[i] = 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(n-1)))
+	}
+
+	return w
+}
+
+// Hamming is a Window similar to Hann, but with non-zero endpoints and lower first side lobe.
+func Hamming(n int) []float64 {
+	w := make([]float64, n)
+	if n == 1 {
+		w[0] = 1
+		return w
+	}
+
+	for i := range w {
+		w[i] = 0.54 - 0.46*math.Cos(2*math.Pi*float64(i)/float64(n-1))
+	}
+
+	return w
+}
+
+// WindowByName returns Window by its configuration `name`. Hann is returned for unknown names.
+func WindowByName(name string) Window {
+	switch strings.ToLower(name) {
+	case "rectangular", "none":
+		return Rectangular
+	case "hamming":
+		return Hamming
+	default:
+		return Hann
+	}
+}
@@ -13,8 +13,10 @@ const (
 	displayUpdateControl1          byte = 0x21
 	displayUpdateControl2          byte = 0x22
 	writeRAM                       byte = 0x24
+	writePreviousRAM               byte = 0x26
 	writeVcomRegister              byte = 0x2C
 	writeLutRegister               byte = 0x32
+	writeDisplayOption             byte = 0x37
 	setDummyLinePeriod             byte = 0x3A
 	setGateTime                    byte = 0x3B
 	borderWaveformControl          byte = 0x3C
@@ -25,3 +27,109 @@ const (
 	setRAMYAddressCounter          byte = 0x4F
 	terminateFrameReadWrite        byte = 0xFF
 )
+
+// EInk display update sequences.
+const (
+	// einkLoadFullLUT loads temperature and full update waveform from OTP memory.
+	einkLoadFullLUT byte = 0xB1
+	// einkFullUpdate performs full update with loaded waveform.
+	einkFullUpdate byte = 0xC4
+	// einkEnableAnalog enables clock and analog, which is required before partial updates.
+	einkEnableAnalog byte = 0xC0
+	// einkPartialUpdate performs update with waveform from LUT register, keeping analog enabled.
+	einkPartialUpdate byte = 0x0C
+)
+
+// einkPartialLUT is a waveform for partial update, which only drives pixels that differ
+// between current (0x24) and previous (0x26) frame memories, so the panel doesn't flash.
+var einkPartialLUT = []byte{
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x0A, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00,
+}
+
+// ST7789 display commands.
+const (
+	st7789SoftwareReset    byte = 0x01
+	st7789SleepIn          byte = 0x10
+	st7789SleepOut         byte = 0x11
+	st7789NormalMode       byte = 0x13
+	st7789InversionOn      byte = 0x21
+	st7789DisplayOn        byte = 0x29
+	st7789ColumnAddressSet byte = 0x2A
+	st7789RowAddressSet    byte = 0x2B
+	st7789MemoryWrite      byte = 0x2C
+	st7789MemoryAccessCtl  byte = 0x36
+	st7789PixelFormat      byte = 0x3A
+)
+
+// ST7789 display constants.
+const (
+	st7789PixelFormat16Bit byte = 0x55
+	st7789MADCTLRotate0    byte = 0x00
+	st7789MADCTLRotate90   byte = 0x60
+	st7789MADCTLRotate180  byte = 0xC0
+	st7789MADCTLRotate270  byte = 0xA0
+
+	// ST7789 controller memory is 240x320, so smaller panels require offset in certain rotations.
+	st7789MemoryWidth  = 240
+	st7789MemoryHeight = 320
+
+	// st7789ChunkSize is a maximum size of a single SPI transfer.
+	st7789ChunkSize = 4096
+	// st7789BacklightFrequency is a PWM frequency of the backlight control.
+	st7789BacklightFrequency = 1000
+)
+
+// SSD1306 and SH1106 OLED display commands.
+const (
+	oledDisplayOff         byte = 0xAE
+	oledDisplayOn          byte = 0xAF
+	oledSetClockDivide     byte = 0xD5
+	oledSetMultiplex       byte = 0xA8
+	oledSetDisplayOffset   byte = 0xD3
+	oledSetStartLine       byte = 0x40
+	oledChargePump         byte = 0x8D
+	oledDCDCControl        byte = 0xAD
+	oledMemoryMode         byte = 0x20
+	oledSegmentRemap       byte = 0xA0
+	oledSegmentRemapFlip   byte = 0xA1
+	oledCOMScanInc         byte = 0xC0
+	oledCOMScanDec         byte = 0xC8
+	oledSetCOMPins         byte = 0xDA
+	oledSetContrast        byte = 0x81
+	oledSetPrecharge       byte = 0xD9
+	oledSetVCOMDetect      byte = 0xDB
+	oledDisplayAllOnResume byte = 0xA4
+	oledNormalDisplay      byte = 0xA6
+	oledSetPageAddress     byte = 0xB0
+	oledSetLowColumn       byte = 0x00
+	oledSetHighColumn      byte = 0x10
+)
+
+// SSD1306 and SH1106 OLED display constants.
+const (
+	// oledCommandControl and oledDataControl are I2C control bytes preceding commands and frame data.
+	oledCommandControl byte = 0x00
+	oledDataControl    byte = 0x40
+
+	oledDefaultAddress   uint16 = 0x3C
+	oledPageHeight              = 8
+	oledPageAddressing   byte   = 0x02
+	oledChargePumpEnable byte   = 0x14
+	oledDCDCEnable       byte   = 0x8B
+	oledSSD1306Precharge byte   = 0xF1
+	oledSH1106Precharge  byte   = 0x22
+
+	// sh1106ColumnOffset is an offset of 128 pixels wide panel in 132 columns of SH1106 memory.
+	sh1106ColumnOffset = 2
+)
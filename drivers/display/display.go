@@ -0,0 +1,32 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+)
+
+// New creates dev.Display driver instance specified by `display.driver` configuration.
+func New(config config.DisplayConfig) (dev.Display, error) {
+	if err := validateRotation(config.Rotation); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(config.Driver) {
+	case "eink", "":
+		return NewEInk(config), nil
+	case "st7789":
+		return NewST7789(config), nil
+	case "ssd1306":
+		return NewSSD1306(config), nil
+	case "sh1106":
+		return NewSH1106(config), nil
+	case "virtual":
+		return NewVirtual(config), nil
+	default:
+		return nil, errors.Errorf("display driver '%s' is not supported", config.Driver)
+	}
+}
@@ -26,6 +26,9 @@ type EInk struct {
 
 	rect image.Rectangle
 
+	// partialMode indicates whether partial update waveform is loaded into LUT register.
+	partialMode bool
+
 	config config.DisplayConfig
 }
 
@@ -81,7 +84,7 @@ func (d *EInk) DrawRaw(r image.Rectangle, src image.Image, sp image.Point) error
 	)
 
 	draw.Src.Draw(bitMap, r, src, sp)
-	for i := 0; i < 3; i++ {
+	for i := 0; i < d.panelTurns(); i++ {
 		bitMap = rotateBitMap(bitMap)
 	}
 
@@ -132,13 +135,55 @@ func (d *EInk) Draw(src image.Image) error {
 }
 
 // DrawAndRefresh sends `src` image binary representation to EInk display buffer
-// and triggers update of the frame.
+// and triggers full update of the frame.
 func (d *EInk) DrawAndRefresh(src image.Image) error {
 	if err := d.Draw(src); err != nil {
 		return err
 	}
 
-	return d.Refresh()
+	if err := d.Refresh(); err != nil {
+		return err
+	}
+
+	// Fully refreshed frame becomes the base for the following partial updates:
+	return d.writeArea(writePreviousRAM, d.panelBitMap(src), d.rect)
+}
+
+// DrawPartial sends `r` region of the `src` image to EInk display buffer
+// and updates it with partial refresh waveform, which is much faster and doesn't flash the panel.
+// Partial updates accumulate ghosting, so full refresh with DrawAndRefresh() must be performed periodically.
+func (d *EInk) DrawPartial(src image.Image, r image.Rectangle) error {
+	var (
+		bitMap = d.panelBitMap(src)
+		area   = d.panelArea(src.Bounds(), r)
+	)
+
+	if area.Empty() {
+		return nil
+	}
+
+	if !d.partialMode {
+		if err := d.loadPartialLUT(); err != nil {
+			return errors.Wrap(err, "failed to load partial update waveform")
+		}
+	}
+
+	if err := d.writeArea(writeRAM, bitMap, area); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(displayUpdateControl2, einkPartialUpdate); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(masterActivation); err != nil {
+		return err
+	}
+
+	d.waitUntilIdle()
+
+	// Previous frame memory must match the displayed content for the next partial update:
+	return d.writeArea(writePreviousRAM, bitMap, area)
 }
 
 // ResetFrameMemory clear the frame memory with the specified color.
@@ -152,26 +197,36 @@ func (d *EInk) ResetFrameMemory(color byte) error {
 	if err := d.setMemoryArea(0, 0, w - 1, h - 1); err != nil {
 		return err
 	}
-	if err := d.setMemoryPointer(0, 0); err != nil {
-		return err
-	}
-	if err := d.SendCommandArgs(writeRAM); err != nil {
-		return err
-	}
 
-	// send the color data
-	for i := 0; i < (w / 8 * h); i++ {
-		if err := d.SendData(color); err != nil {
+	// Both current and previous frame memories are reset, so that following partial update won't restore old content:
+	for _, ram := range []byte{writeRAM, writePreviousRAM} {
+		if err := d.setMemoryPointer(0, 0); err != nil {
+			return err
+		}
+		if err := d.SendCommandArgs(ram); err != nil {
 			return err
 		}
+
+		// send the color data
+		for i := 0; i < (w / 8 * h); i++ {
+			if err := d.SendData(color); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-// Refresh updates the EInk display.
+// Refresh fully updates the EInk display.
 func (d *EInk) Refresh() error {
-	if err := d.SendCommandArgs(displayUpdateControl2, 0xC4); err != nil {
+	if d.partialMode {
+		if err := d.loadFullLUT(); err != nil {
+			return errors.Wrap(err, "failed to load full update waveform")
+		}
+	}
+
+	if err := d.SendCommandArgs(displayUpdateControl2, einkFullUpdate); err != nil {
 		return err
 	}
 	
@@ -238,9 +293,9 @@ func (d *EInk) ColorModel() color.Model {
 	return image1bit.BitModel
 }
 
-// Bounds implements display.Drawer. Min is guaranteed to be {0, 0}.
+// Bounds implements display.Drawer with respect of the display rotation. Min is guaranteed to be {0, 0}.
 func (d *EInk) Bounds() image.Rectangle {
-	return image.Rect(0, 0, d.rect.Dy(), d.rect.Dx())
+	return rotateBounds(d.rect, d.panelTurns())
 }
 
 // SendCommandArgs overrides periphery.SPI send command with args method
@@ -355,7 +410,7 @@ func (d *EInk) init() error {
 		return err
 	}
 
-	if err := d.SendCommandArgs(displayUpdateControl2, 0xB1); err != nil {
+	if err := d.SendCommandArgs(displayUpdateControl2, einkLoadFullLUT); err != nil {
 		return err
 	}
 
@@ -365,7 +420,9 @@ func (d *EInk) init() error {
 
 	d.waitUntilIdle()
 
-	if err := d.SendCommandArgs(displayUpdateControl2, 0xB1); err != nil {
+	d.partialMode = false
+
+	if err := d.SendCommandArgs(displayUpdateControl2, einkLoadFullLUT); err != nil {
 		return err
 	}
 
@@ -376,6 +433,115 @@ func (d *EInk) init() error {
 	return d.setMemoryPointer(0, 0)
 }
 
+// loadPartialLUT writes partial update waveform into LUT register.
+func (d *EInk) loadPartialLUT() error {
+	if err := d.SendCommandArgs(writeVcomRegister, 0x26); err != nil {
+		return err
+	}
+	d.waitUntilIdle()
+
+	if err := d.SendCommandArgs(writeLutRegister, einkPartialLUT...); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(writeDisplayOption, 0x00, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(displayUpdateControl2, einkEnableAnalog); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(masterActivation); err != nil {
+		return err
+	}
+	d.waitUntilIdle()
+
+	d.partialMode = true
+
+	return nil
+}
+
+// loadFullLUT restores full update waveform from OTP memory into LUT register.
+func (d *EInk) loadFullLUT() error {
+	if err := d.SendCommandArgs(displayUpdateControl2, einkLoadFullLUT); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(masterActivation); err != nil {
+		return err
+	}
+	d.waitUntilIdle()
+
+	d.partialMode = false
+
+	return nil
+}
+
+// panelBitMap converts `src` image into binary bitmap rotated to match the EInk panel memory layout.
+func (d *EInk) panelBitMap(src image.Image) *image1bit.VerticalLSB {
+	bitMap := image1bit.NewVerticalLSB(src.Bounds())
+
+	draw.Src.Draw(bitMap, src.Bounds(), src, src.Bounds().Min)
+	for i := 0; i < d.panelTurns(); i++ {
+		bitMap = rotateBitMap(bitMap)
+	}
+
+	return bitMap
+}
+
+// panelTurns determines number of clockwise 90 degrees turns required to map frame onto the EInk panel memory.
+// Panel memory is in portrait orientation, so landscape frame with zero rotation is turned by 270 degrees.
+func (d *EInk) panelTurns() int {
+	return (3 + quarterTurns(d.config.Rotation)) % 4
+}
+
+// panelArea converts `r` region of the `frame` into EInk panel memory coordinates,
+// where X axis is aligned to bytes.
+func (d *EInk) panelArea(frame, r image.Rectangle) image.Rectangle {
+	var area = rotateRect(r, frame, d.panelTurns())
+
+	area.Min.X = area.Min.X / 8 * 8
+	area.Max.X = (area.Max.X + 7) / 8 * 8
+
+	return area.Intersect(d.rect)
+}
+
+// writeArea sends `area` of the `bitMap` in panel coordinates to the specified `ram` frame memory.
+func (d *EInk) writeArea(ram byte, bitMap *image1bit.VerticalLSB, area image.Rectangle) error {
+	if err := d.setMemoryArea(area.Min.X, area.Min.Y, area.Max.X - 1, area.Max.Y - 1); err != nil {
+		return err
+	}
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		var (
+			row = make([]byte, 0, area.Dx() / 8)
+			b   byte
+		)
+
+		for x := area.Min.X; x < area.Max.X; x++ {
+			if bitMap.BitAt(x, y) {
+				b |= 0x80 >> (uint32(x) % 8)
+			}
+
+			if x%8 == 7 {
+				row = append(row, b)
+				b = 0x00
+			}
+		}
+
+		if err := d.setMemoryPointer(area.Min.X, y); err != nil {
+			return err
+		}
+
+		if err := d.SendCommandArgs(ram, row...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func rotateBitMap(bitMap *image1bit.VerticalLSB) *image1bit.VerticalLSB {
 	next := image1bit.NewVerticalLSB(image.Rect(
 		bitMap.Rect.Min.Y,
@@ -0,0 +1,156 @@
+package display
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+)
+
+// Memory is an implementation of dev.Display driver, which keeps frames in memory only.
+// It is intended for GUI rendering tests, where displayed frames are compared with the expected ones.
+type Memory struct {
+	mutex     *sync.Mutex
+	buffer    *image.RGBA
+	frame     *image.RGBA
+	model     color.Model
+	refreshes int
+	active    bool
+}
+
+// NewMemory creates new Memory driver instance with given dimensions and `model` color model,
+// which is applied to the drawn images, so that frames look the same as on the real display of that kind.
+func NewMemory(width, height int, model color.Model) *Memory {
+	return &Memory{
+		mutex:  &sync.Mutex{},
+		buffer: image.NewRGBA(image.Rect(0, 0, width, height)),
+		frame:  image.NewRGBA(image.Rect(0, 0, width, height)),
+		model:  model,
+	}
+}
+
+// Init activates Memory display.
+func (d *Memory) Init() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.active = true
+
+	return nil
+}
+
+// Draw renders `src` image into Memory display buffer, converting it to display color model.
+// Use Refresh() or DrawAndRefresh() to make it the displayed frame.
+func (d *Memory) Draw(src image.Image) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var (
+		b      = d.buffer.Bounds().Intersect(src.Bounds().Sub(src.Bounds().Min))
+		offset = src.Bounds().Min
+	)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			d.buffer.Set(x, y, d.model.Convert(src.At(x+offset.X, y+offset.Y)))
+		}
+	}
+
+	return nil
+}
+
+// DrawAndRefresh renders `src` image into Memory display and makes it the displayed frame.
+func (d *Memory) DrawAndRefresh(src image.Image) error {
+	if err := d.Draw(src); err != nil {
+		return err
+	}
+
+	return d.Refresh()
+}
+
+// Refresh makes the buffer content the displayed frame.
+func (d *Memory) Refresh() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	draw.Draw(d.frame, d.frame.Bounds(), d.buffer, image.Point{}, draw.Src)
+	d.refreshes++
+
+	return nil
+}
+
+// Clear fills Memory display buffer with white color.
+func (d *Memory) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	draw.Draw(d.buffer, d.buffer.Bounds(), image.White, image.Point{}, draw.Src)
+
+	return nil
+}
+
+// ClearAndRefresh clears Memory display buffer and makes it the displayed frame.
+func (d *Memory) ClearAndRefresh() error {
+	if err := d.Clear(); err != nil {
+		return err
+	}
+
+	return d.Refresh()
+}
+
+// Sleep does nothing, since Memory display consumes no power.
+func (d *Memory) Sleep() error {
+	return nil
+}
+
+// Reset does nothing, since Memory display has no hardware state.
+func (d *Memory) Reset() error {
+	return nil
+}
+
+// ColorModel returns color model of the Memory display.
+func (d *Memory) ColorModel() color.Model {
+	return d.model
+}
+
+// Bounds returns Memory display dimensions.
+func (d *Memory) Bounds() image.Rectangle {
+	return d.buffer.Bounds()
+}
+
+// Active checks whether the Memory display is initialized.
+func (d *Memory) Active() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.active
+}
+
+// Close deactivates Memory display.
+func (d *Memory) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.active = false
+
+	return nil
+}
+
+// Frame returns copy of the currently displayed frame.
+func (d *Memory) Frame() *image.RGBA {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	frame := image.NewRGBA(d.frame.Bounds())
+	draw.Draw(frame, frame.Bounds(), d.frame, image.Point{}, draw.Src)
+
+	return frame
+}
+
+// Refreshes returns number of frames displayed since Memory display creation.
+func (d *Memory) Refreshes() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.refreshes
+}
@@ -0,0 +1,63 @@
+package display
+
+import (
+	"image"
+
+	"github.com/pkg/errors"
+)
+
+// validateRotation checks whether `rotation` in degrees is supported by display drivers.
+func validateRotation(rotation uint16) error {
+	switch rotation {
+	case 0, 90, 180, 270:
+		return nil
+	default:
+		return errors.Errorf("display rotation %d is not supported, must be one of 0, 90, 180, or 270", rotation)
+	}
+}
+
+// quarterTurns converts `rotation` in degrees to the number of clockwise 90 degrees turns.
+func quarterTurns(rotation uint16) int {
+	return int(rotation/90) % 4
+}
+
+// rotateBounds returns bounds of the `rect` after `turns` clockwise 90 degrees turns.
+func rotateBounds(rect image.Rectangle, turns int) image.Rectangle {
+	if turns%2 == 1 {
+		return image.Rect(0, 0, rect.Dy(), rect.Dx())
+	}
+
+	return image.Rect(0, 0, rect.Dx(), rect.Dy())
+}
+
+// rotateRect maps `r` region of the `frame` onto the frame rotated clockwise by `turns` 90 degrees turns.
+func rotateRect(r, frame image.Rectangle, turns int) image.Rectangle {
+	for i := 0; i < turns%4; i++ {
+		h := frame.Dy()
+		r = image.Rect(h-r.Max.Y, r.Min.X, h-r.Min.Y, r.Max.X)
+		frame = rotateBounds(frame, 1)
+	}
+
+	return r
+}
+
+// rotateImage rotates `src` image clockwise by `turns` 90 degrees turns.
+func rotateImage(src image.Image, turns int) image.Image {
+	if turns%4 == 0 {
+		return src
+	}
+
+	var (
+		b   = src.Bounds()
+		dst = image.NewRGBA(rotateBounds(b, turns))
+	)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			p := rotateRect(image.Rect(x-b.Min.X, y-b.Min.Y, x-b.Min.X+1, y-b.Min.Y+1), b, turns).Min
+			dst.Set(p.X, p.Y, src.At(x, y))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,276 @@
+package display
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"github.com/pkg/errors"
+	"periph.io/x/periph/devices/ssd1306/image1bit"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev"
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+)
+
+var oledMutex = &sync.Mutex{}
+
+// OLED is an implementation of dev.Display driver for SSD1306 and SH1106 monochrome I2C OLED displays.
+//
+// Since OLED pixels are emitting light, the dark pixels of the drawn image are the ones being lit,
+// so that GUI frames designed for paper-like displays are rendered with light content on dark background.
+type OLED struct {
+	*periphery.I2C
+
+	name   string
+	sh1106 bool
+	rect   image.Rectangle
+	buffer []byte
+
+	config config.DisplayConfig
+}
+
+// NewSSD1306 creates new OLED driver instance for SSD1306 chip by implementing dev.Display interface.
+func NewSSD1306(config config.DisplayConfig) dev.Display {
+	return newOLED("SSD1306", false, config)
+}
+
+// NewSH1106 creates new OLED driver instance for SH1106 chip by implementing dev.Display interface.
+func NewSH1106(config config.DisplayConfig) dev.Display {
+	return newOLED("SH1106", true, config)
+}
+
+func newOLED(name string, sh1106 bool, config config.DisplayConfig) *OLED {
+	var addr = config.Address
+
+	if addr == 0 {
+		addr = oledDefaultAddress
+	}
+
+	return &OLED{
+		I2C:    periphery.NewI2C(addr, config.I2CBus, periphery.WithMutex(oledMutex)),
+		name:   name,
+		sh1106: sh1106,
+		rect:   image.Rect(0, 0, config.Width, config.Height),
+		buffer: make([]byte, config.Width*config.Height/oledPageHeight),
+		config: config,
+	}
+}
+
+// Init performs OLED display device initialization.
+func (d *OLED) Init() error {
+	if err := d.I2C.Init(); err != nil {
+		return errors.Wrapf(err, "error during connecting to %s display via I2C", d.name)
+	}
+
+	if err := d.init(); err != nil {
+		return errors.Wrapf(err, "error during initialising to %s display driver", d.name)
+	}
+
+	return d.Clear()
+}
+
+// Draw converts `src` image into pages buffer and sends it to OLED display.
+// Since OLED displays frame memory content right away, no Refresh() is needed.
+func (d *OLED) Draw(src image.Image) error {
+	src = rotateImage(src, d.softwareTurns())
+
+	var r = src.Bounds().Intersect(d.rect)
+
+	for i := range d.buffer {
+		d.buffer[i] = 0
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if !image1bit.BitModel.Convert(src.At(x, y)).(image1bit.Bit) {
+				d.buffer[y/oledPageHeight*d.rect.Dx()+x] |= 1 << (y % oledPageHeight)
+			}
+		}
+	}
+
+	return d.flush()
+}
+
+// DrawAndRefresh sends `src` image to OLED display. It is the same as Draw().
+func (d *OLED) DrawAndRefresh(src image.Image) error {
+	return d.Draw(src)
+}
+
+// Refresh does nothing, since OLED display updates on each Draw().
+func (d *OLED) Refresh() error {
+	return nil
+}
+
+// Clear turns off all pixels of the OLED display.
+func (d *OLED) Clear() error {
+	for i := range d.buffer {
+		d.buffer[i] = 0
+	}
+
+	return d.flush()
+}
+
+// ClearAndRefresh clears the OLED display. It is the same as Clear().
+func (d *OLED) ClearAndRefresh() error {
+	return d.Clear()
+}
+
+// Sleep turns off OLED display panel to save power.
+// Use Reset() to awaken the device.
+func (d *OLED) Sleep() error {
+	return d.SendCommands(oledDisplayOff)
+}
+
+// Reset performs software reset of the OLED display by repeating its initialization sequence,
+// since I2C modules usually don't expose reset pin.
+func (d *OLED) Reset() error {
+	return d.init()
+}
+
+// SetBrightness sets OLED display brightness in percents by changing its contrast.
+func (d *OLED) SetBrightness(percent uint8) error {
+	if percent > 100 {
+		percent = 100
+	}
+
+	return d.SendCommands(oledSetContrast, byte(int(percent)*0xFF/100))
+}
+
+// ColorModel returns color model of the OLED display.
+func (d *OLED) ColorModel() color.Model {
+	return image1bit.BitModel
+}
+
+// Bounds returns OLED display dimensions with respect of its rotation. Min is guaranteed to be {0, 0}.
+func (d *OLED) Bounds() image.Rectangle {
+	return rotateBounds(d.rect, d.softwareTurns())
+}
+
+// Close turns off OLED display and closes connection to it.
+func (d *OLED) Close() error {
+	if err := d.Sleep(); err != nil {
+		return errors.Wrapf(err, "error during turning off %s display", d.name)
+	}
+
+	return d.I2C.Close()
+}
+
+// SendCommands sends `cmd` command bytes sequence to OLED display.
+func (d *OLED) SendCommands(cmd ...byte) error {
+	if !d.Active() {
+		return nil
+	}
+
+	return d.WriteRegBytes(oledCommandControl, cmd...)
+}
+
+// SendData sends `data` bytes to OLED display memory.
+func (d *OLED) SendData(data ...byte) error {
+	if !d.Active() {
+		return nil
+	}
+
+	return d.WriteRegBytes(oledDataControl, data...)
+}
+
+// init performs sequence of commands to initialise OLED display chip.
+func (d *OLED) init() error {
+	var (
+		segmentRemap      = oledSegmentRemapFlip
+		comScan           = oledCOMScanDec
+		comPins      byte = 0x12
+		precharge         = oledSSD1306Precharge
+		power             = []byte{oledChargePump, oledChargePumpEnable}
+	)
+
+	// Both chips support only 180 degrees rotation by mirroring, so the rest is done by softwareTurns():
+	if d.config.Rotation >= 180 {
+		segmentRemap, comScan = oledSegmentRemap, oledCOMScanInc
+	}
+
+	if d.rect.Dy() == 32 {
+		comPins = 0x02
+	}
+
+	if d.sh1106 {
+		precharge = oledSH1106Precharge
+		power = []byte{oledDCDCControl, oledDCDCEnable}
+	}
+
+	if err := d.SendCommands(oledDisplayOff); err != nil {
+		return err
+	}
+
+	if err := d.SendCommands(
+		oledSetClockDivide, 0x80,
+		oledSetMultiplex, byte(d.rect.Dy()-1),
+		oledSetDisplayOffset, 0x00,
+		oledSetStartLine,
+	); err != nil {
+		return err
+	}
+
+	if err := d.SendCommands(power...); err != nil {
+		return err
+	}
+
+	// Page addressing is the only mode supported by both chips:
+	if !d.sh1106 {
+		if err := d.SendCommands(oledMemoryMode, oledPageAddressing); err != nil {
+			return err
+		}
+	}
+
+	if err := d.SendCommands(
+		segmentRemap,
+		comScan,
+		oledSetCOMPins, comPins,
+		oledSetPrecharge, precharge,
+		oledSetVCOMDetect, 0x40,
+		oledDisplayAllOnResume,
+		oledNormalDisplay,
+	); err != nil {
+		return err
+	}
+
+	if err := d.SetBrightness(d.config.Brightness); err != nil {
+		return err
+	}
+
+	return d.SendCommands(oledDisplayOn)
+}
+
+// softwareTurns determines number of clockwise 90 degrees turns of the frame,
+// which cannot be performed by the chip itself.
+func (d *OLED) softwareTurns() int {
+	return quarterTurns(d.config.Rotation) % 2
+}
+
+// flush sends pages buffer to the OLED display memory page by page.
+func (d *OLED) flush() error {
+	var (
+		width  = d.rect.Dx()
+		column = 0
+	)
+
+	if d.sh1106 {
+		column = sh1106ColumnOffset
+	}
+
+	for page := 0; page < d.rect.Dy()/oledPageHeight; page++ {
+		if err := d.SendCommands(
+			oledSetPageAddress|byte(page),
+			oledSetLowColumn|byte(column&0x0F),
+			oledSetHighColumn|byte(column>>4),
+		); err != nil {
+			return err
+		}
+
+		if err := d.SendData(d.buffer[page*width : (page+1)*width]...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,330 @@
+package display
+
+import (
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/pkg/errors"
+	"periph.io/x/periph/conn/gpio"
+	"periph.io/x/periph/conn/physic"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev"
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+)
+
+// ST7789 is an implementation of dev.Display driver for ST7789 color IPS LCD display.
+type ST7789 struct {
+	*periphery.SPI
+
+	dc        *periphery.GPIO
+	rst       *periphery.GPIO
+	backlight *periphery.GPIO
+
+	rect    image.Rectangle
+	offsetX int
+	offsetY int
+	madctl  byte
+
+	config config.DisplayConfig
+}
+
+// NewST7789 creates new ST7789 driver instance by implementing dev.Display interface.
+func NewST7789(config config.DisplayConfig) dev.Display {
+	d := &ST7789{
+		SPI:       periphery.NewSPI(config.Bus),
+		dc:        periphery.NewGPIO(config.DCPin),
+		rst:       periphery.NewGPIO(config.ResetPin),
+		backlight: periphery.NewGPIO(config.BacklightPin),
+		config:    config,
+	}
+
+	d.setRotation(config.Rotation)
+
+	return d
+}
+
+// Init performs ST7789 display device initialization.
+func (d *ST7789) Init() error {
+	if err := d.dc.Init(); err != nil {
+		return errors.Wrap(err, "error during connecting to ST7789 display DC pin")
+	}
+
+	if err := d.rst.Init(); err != nil {
+		return errors.Wrap(err, "error during connecting to ST7789 display RST pin")
+	}
+
+	if err := d.backlight.Init(); err != nil {
+		return errors.Wrap(err, "error during connecting to ST7789 display backlight pin")
+	}
+
+	if err := d.SPI.Init(); err != nil {
+		return errors.Wrap(err, "error during connecting to ST7789 display via SPI")
+	}
+
+	if err := d.init(); err != nil {
+		return errors.Wrap(err, "error during initialising to ST7789 display driver")
+	}
+
+	if err := d.SetBrightness(d.config.Brightness); err != nil {
+		return errors.Wrap(err, "error during setting ST7789 display backlight")
+	}
+
+	return d.Clear()
+}
+
+// Draw sends `src` image in RGB565 format to ST7789 display.
+// Since LCD displays frame memory content right away, no Refresh() is needed.
+func (d *ST7789) Draw(src image.Image) error {
+	var (
+		r      = src.Bounds().Intersect(d.rect)
+		pixels = make([]byte, 0, r.Dx()*r.Dy()*2)
+	)
+
+	if r.Empty() {
+		return nil
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := toRGB565(src.At(x, y))
+			pixels = append(pixels, byte(c>>8), byte(c))
+		}
+	}
+
+	return d.drawPixels(r, pixels)
+}
+
+// DrawAndRefresh sends `src` image to ST7789 display. It is the same as Draw().
+func (d *ST7789) DrawAndRefresh(src image.Image) error {
+	return d.Draw(src)
+}
+
+// Refresh does nothing, since ST7789 display updates on each Draw().
+func (d *ST7789) Refresh() error {
+	return nil
+}
+
+// Clear fills ST7789 display with black color.
+func (d *ST7789) Clear() error {
+	return d.drawPixels(d.rect, make([]byte, d.rect.Dx()*d.rect.Dy()*2))
+}
+
+// ClearAndRefresh clears the ST7789 display. It is the same as Clear().
+func (d *ST7789) ClearAndRefresh() error {
+	return d.Clear()
+}
+
+// Sleep puts ST7789 display to sleep mode and turns off backlight to save power.
+// Use Reset() to awaken and Init to re-initialize the device.
+func (d *ST7789) Sleep() error {
+	if err := d.SetBrightness(0); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(st7789SleepIn); err != nil {
+		return err
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	return nil
+}
+
+// Reset performs hardware reset of the ST7789 display.
+func (d *ST7789) Reset() (err error) {
+	if err = d.rst.Out(gpio.High); err != nil {
+		return
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if err = d.rst.Out(gpio.Low); err != nil {
+		return
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if err = d.rst.Out(gpio.High); err != nil {
+		return
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	return
+}
+
+// SetBrightness sets ST7789 display backlight brightness in percents with PWM.
+// If PWM isn't supported by the backlight pin, any non-zero brightness turns backlight fully on.
+func (d *ST7789) SetBrightness(percent uint8) error {
+	if percent == 0 {
+		return d.backlight.Low()
+	}
+
+	if percent >= 100 {
+		return d.backlight.High()
+	}
+
+	duty := gpio.Duty(int32(percent) * int32(gpio.DutyMax) / 100)
+	if err := d.backlight.PWM(duty, st7789BacklightFrequency*physic.Hertz); err != nil {
+		return d.backlight.High()
+	}
+
+	return nil
+}
+
+// ColorModel returns color model of the ST7789 display.
+func (d *ST7789) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds returns ST7789 display dimensions with respect of its rotation. Min is guaranteed to be {0, 0}.
+func (d *ST7789) Bounds() image.Rectangle {
+	return d.rect
+}
+
+// Close turns off ST7789 display backlight and closes connection to it.
+func (d *ST7789) Close() error {
+	if err := d.backlight.Low(); err != nil {
+		return errors.Wrap(err, "error during turning off ST7789 display backlight")
+	}
+
+	return d.SPI.Close()
+}
+
+// SendCommandArgs overrides periphery.SPI send command with args method
+// by additionally sending signals to DC GPIO pin.
+func (d *ST7789) SendCommandArgs(cmd byte, data ...byte) error {
+	if !d.Active() {
+		return nil
+	}
+
+	if err := d.dc.Low(); err != nil {
+		return errors.Wrapf(err, "error during sending %s signal to %s", gpio.Low, d.dc)
+	}
+
+	if err := d.SPI.SendCommand(cmd); err != nil {
+		return err
+	}
+
+	return d.SendData(data...)
+}
+
+// SendData overrides periphery.SPI send data method
+// by additionally sending signals to DC GPIO pin and splitting data into chunks.
+func (d *ST7789) SendData(data ...byte) error {
+	if !d.Active() || len(data) == 0 {
+		return nil
+	}
+
+	if err := d.dc.High(); err != nil {
+		return errors.Wrapf(err, "error during sending %s signal to %s", gpio.High, d.dc)
+	}
+
+	for start := 0; start < len(data); start += st7789ChunkSize {
+		end := start + st7789ChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		if err := d.SPI.SendData(data[start:end]...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// init performs sequence of commands to initialise ST7789 display chip.
+func (d *ST7789) init() error {
+	if err := d.Reset(); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(st7789SoftwareReset); err != nil {
+		return err
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	if err := d.SendCommandArgs(st7789SleepOut); err != nil {
+		return err
+	}
+	time.Sleep(120 * time.Millisecond)
+
+	if err := d.SendCommandArgs(st7789PixelFormat, st7789PixelFormat16Bit); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(st7789MemoryAccessCtl, d.madctl); err != nil {
+		return err
+	}
+
+	// IPS panels require inverted colors to be displayed properly:
+	if err := d.SendCommandArgs(st7789InversionOn); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(st7789NormalMode); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(st7789DisplayOn); err != nil {
+		return err
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	return nil
+}
+
+// setRotation determines memory access control value, bounds and memory offsets for the given `rotation` in degrees.
+func (d *ST7789) setRotation(rotation uint16) {
+	var (
+		w, h = d.config.Width, d.config.Height
+	)
+
+	switch rotation {
+	case 90:
+		d.madctl = st7789MADCTLRotate90
+		d.rect = image.Rect(0, 0, h, w)
+	case 180:
+		d.madctl = st7789MADCTLRotate180
+		d.rect = image.Rect(0, 0, w, h)
+		d.offsetY = st7789MemoryHeight - h
+		d.offsetX = st7789MemoryWidth - w
+	case 270:
+		d.madctl = st7789MADCTLRotate270
+		d.rect = image.Rect(0, 0, h, w)
+		d.offsetX = st7789MemoryHeight - h
+		d.offsetY = st7789MemoryWidth - w
+	default:
+		d.madctl = st7789MADCTLRotate0
+		d.rect = image.Rect(0, 0, w, h)
+	}
+}
+
+// drawPixels writes RGB565 `pixels` into the `r` window of the display memory.
+func (d *ST7789) drawPixels(r image.Rectangle, pixels []byte) error {
+	var (
+		x0, x1 = r.Min.X + d.offsetX, r.Max.X - 1 + d.offsetX
+		y0, y1 = r.Min.Y + d.offsetY, r.Max.Y - 1 + d.offsetY
+	)
+
+	if err := d.SendCommandArgs(st7789ColumnAddressSet,
+		byte(x0>>8), byte(x0), byte(x1>>8), byte(x1),
+	); err != nil {
+		return err
+	}
+
+	if err := d.SendCommandArgs(st7789RowAddressSet,
+		byte(y0>>8), byte(y0), byte(y1>>8), byte(y1),
+	); err != nil {
+		return err
+	}
+
+	return d.SendCommandArgs(st7789MemoryWrite, pixels...)
+}
+
+// toRGB565 converts color `c` into 16-bit RGB565 format.
+func toRGB565(c color.Color) uint16 {
+	r, g, b, _ := c.RGBA()
+
+	return uint16((r>>11)<<11 | (g>>10)<<5 | b>>11)
+}
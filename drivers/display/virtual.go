@@ -0,0 +1,269 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// Virtual is an implementation of dev.Display driver, which renders frames into memory
+// and saves them as PNG images with rolling history. Optionally, latest frame is served over HTTP.
+// It is intended for developing and testing GUI on machines without display panel connected.
+//
+// Frames are rotated by `display.rotation` the same way as on the panel, and are saved in its native orientation.
+// History is restored from the output directory on Init, so that it's kept rolling across restarts.
+type Virtual struct {
+	mutex   *sync.RWMutex
+	buffer  *image.RGBA
+	latest  []byte
+	history []string
+	counter int
+	server  *http.Server
+	active  bool
+
+	config config.DisplayConfig
+}
+
+// NewVirtual creates new Virtual driver instance by implementing dev.Display interface.
+func NewVirtual(config config.DisplayConfig) dev.Display {
+	return &Virtual{
+		mutex:  &sync.RWMutex{},
+		buffer: image.NewRGBA(image.Rect(0, 0, config.Width, config.Height)),
+		config: config,
+	}
+}
+
+// Init prepares output directory and starts HTTP server if listen address is specified.
+func (d *Virtual) Init() error {
+	if err := os.MkdirAll(d.config.Virtual.OutputDir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create virtual display output directory '%s'", d.config.Virtual.OutputDir)
+	}
+
+	if err := d.restoreHistory(); err != nil {
+		return errors.Wrap(err, "failed to restore virtual display frames history")
+	}
+
+	if len(d.config.Virtual.ListenAddress) != 0 {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", d.serveIndex)
+		mux.HandleFunc("/frame.png", d.serveFrame)
+
+		d.server = &http.Server{
+			Addr:    d.config.Virtual.ListenAddress,
+			Handler: mux,
+		}
+
+		go func() {
+			if err := d.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				shared.Logger.Error(errors.Wrap(err, "virtual display HTTP server failed"))
+			}
+		}()
+
+		shared.Logger.Infof("Virtual display is available on http://%s", d.config.Virtual.ListenAddress)
+	}
+
+	d.active = true
+
+	return d.Clear()
+}
+
+// Draw renders `src` image into Virtual display memory. Use Refresh() or DrawAndRefresh() to save frame.
+func (d *Virtual) Draw(src image.Image) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	src = rotateImage(src, quarterTurns(d.config.Rotation))
+
+	draw.Draw(d.buffer, d.buffer.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	return nil
+}
+
+// DrawAndRefresh renders `src` image into Virtual display memory and saves frame.
+func (d *Virtual) DrawAndRefresh(src image.Image) error {
+	if err := d.Draw(src); err != nil {
+		return err
+	}
+
+	return d.Refresh()
+}
+
+// Refresh encodes current frame to PNG and saves it to the output directory,
+// removing the oldest frames exceeding history size.
+func (d *Virtual) Refresh() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var buffer = bytes.Buffer{}
+
+	if err := png.Encode(&buffer, d.buffer); err != nil {
+		return errors.Wrap(err, "failed to encode virtual display frame")
+	}
+
+	d.latest = buffer.Bytes()
+	d.counter++
+
+	var (
+		dir  = d.config.Virtual.OutputDir
+		path = filepath.Join(dir, fmt.Sprintf("frame-%06d.png", d.counter))
+	)
+
+	if err := ioutil.WriteFile(path, d.latest, 0644); err != nil {
+		return errors.Wrapf(err, "failed to save virtual display frame to '%s'", path)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "latest.png"), d.latest, 0644); err != nil {
+		return errors.Wrap(err, "failed to save latest virtual display frame")
+	}
+
+	d.history = append(d.history, path)
+	d.trimHistory()
+
+	return nil
+}
+
+// Clear fills Virtual display memory with white color.
+func (d *Virtual) Clear() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	draw.Draw(d.buffer, d.buffer.Bounds(), image.White, image.Point{}, draw.Src)
+
+	return nil
+}
+
+// ClearAndRefresh clears Virtual display memory and saves blank frame.
+func (d *Virtual) ClearAndRefresh() error {
+	if err := d.Clear(); err != nil {
+		return err
+	}
+
+	return d.Refresh()
+}
+
+// Sleep does nothing, since Virtual display doesn't consume power.
+func (d *Virtual) Sleep() error {
+	return nil
+}
+
+// Reset does nothing, since Virtual display has no hardware to reset.
+func (d *Virtual) Reset() error {
+	return nil
+}
+
+// ColorModel returns color model of the Virtual display.
+func (d *Virtual) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds returns Virtual display dimensions with respect to its rotation. Min is guaranteed to be {0, 0}.
+func (d *Virtual) Bounds() image.Rectangle {
+	return rotateBounds(d.buffer.Bounds(), quarterTurns(d.config.Rotation))
+}
+
+// Active checks whether the Virtual display is initialized.
+func (d *Virtual) Active() bool {
+	return d.active
+}
+
+// Close stops HTTP server of the Virtual display.
+func (d *Virtual) Close() error {
+	d.active = false
+
+	if d.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return d.server.Shutdown(ctx)
+}
+
+// restoreHistory collects frames saved to the output directory before, so that numbering continues after them.
+func (d *Virtual) restoreHistory() error {
+	paths, err := filepath.Glob(filepath.Join(d.config.Virtual.OutputDir, "frame-*.png"))
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(paths) // Frame numbers are zero-padded, so lexical order is the chronological one.
+
+	d.history = paths
+	d.counter = 0
+
+	if len(paths) != 0 {
+		_, _ = fmt.Sscanf(filepath.Base(paths[len(paths)-1]), "frame-%d.png", &d.counter)
+	}
+
+	d.trimHistory()
+
+	return nil
+}
+
+// trimHistory removes the oldest frames exceeding history size.
+func (d *Virtual) trimHistory() {
+	for d.config.Virtual.HistorySize > 0 && len(d.history) > d.config.Virtual.HistorySize {
+		if err := os.Remove(d.history[0]); err != nil && !os.IsNotExist(err) {
+			shared.Logger.Warning(errors.Wrapf(err, "failed to remove old virtual display frame '%s'", d.history[0]))
+		}
+
+		d.history = d.history[1:]
+	}
+}
+
+func (d *Virtual) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, virtualDisplayPage, d.buffer.Bounds().Dx()*2)
+}
+
+func (d *Virtual) serveFrame(w http.ResponseWriter, _ *http.Request) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	if d.latest == nil {
+		http.Error(w, "no frame has been rendered yet", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(d.latest)
+}
+
+// virtualDisplayPage is an HTML page displaying latest frame, which is reloaded every second.
+const virtualDisplayPage = `<!DOCTYPE html>
+<html>
+<head><title>Chainmetric virtual display</title></head>
+<body style="margin: 2em; background: #888;">
+<img id="frame" src="frame.png" width="%d" style="image-rendering: pixelated;">
+<script>
+setInterval(function () {
+	document.getElementById("frame").src = "frame.png?t=" + Date.now();
+}, 1000);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,95 @@
+package input
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"periph.io/x/periph/conn/gpio"
+
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+)
+
+// Action defines type of the button interaction.
+type Action int
+
+const (
+	// Press identifies button being pressed and released shortly.
+	Press Action = iota
+	// LongPress identifies button being held for the long press duration.
+	LongPress
+)
+
+// Button is a driver for physical push button connected between GPIO pin and ground,
+// so that internal pull-up resistor is used and pressed button reads as Low.
+type Button struct {
+	*periphery.GPIO
+
+	name      string
+	debounce  time.Duration
+	longPress time.Duration
+}
+
+// NewButton constructs new Button driver instance.
+func NewButton(name string, pin int, debounce, longPress time.Duration) *Button {
+	return &Button{
+		GPIO:      periphery.NewGPIO(pin),
+		name:      name,
+		debounce:  debounce,
+		longPress: longPress,
+	}
+}
+
+// Name returns name of the Button.
+func (b *Button) Name() string {
+	return b.name
+}
+
+// Init performs Button pin initialization.
+func (b *Button) Init() error {
+	if err := b.InitInput(gpio.PullUp, gpio.BothEdges); err != nil {
+		return errors.Wrapf(err, "failed to initialize '%s' button", b.name)
+	}
+
+	return nil
+}
+
+// Listen waits for the Button interactions and passes them to `handler` until `ctx` is done.
+// Contact bouncing is filtered by ignoring state changes shorter than debounce duration.
+// Long press is reported as soon as the Button is held long enough, without waiting for release.
+func (b *Button) Listen(ctx context.Context, handler func(action Action)) {
+	for ctx.Err() == nil {
+		// Timeout allows to periodically check whether the context is done:
+		if !b.WaitForEdge(time.Second) || !b.stable(gpio.Low) {
+			continue
+		}
+
+		var (
+			pressed  = time.Now()
+			released = false
+		)
+
+		for !released && time.Since(pressed) < b.longPress {
+			released = b.WaitForEdge(b.longPress-time.Since(pressed)) && b.stable(gpio.High)
+		}
+
+		if released {
+			handler(Press)
+			continue
+		}
+
+		handler(LongPress)
+
+		// Wait for release, so that held button won't be reported again:
+		for ctx.Err() == nil && !b.stable(gpio.High) {
+			b.WaitForEdge(time.Second)
+		}
+	}
+}
+
+// stable waits for debounce duration and checks whether the Button pin settled on `level`.
+func (b *Button) stable(level gpio.Level) bool {
+	time.Sleep(b.debounce)
+
+	return b.Read() == level
+}
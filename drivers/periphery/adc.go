@@ -33,6 +33,8 @@ type ADC interface {
 	Max(n int, t *time.Duration) float64
 	// Min returns min value from `n` analog sensor readings.
 	Min(n int, t *time.Duration) float64
+	// Sequence returns `n` converted analog sensor readings along with time taken to sample them.
+	Sequence(n int, t *time.Duration) ([]float64, time.Duration)
 	// Verify identifies ADC device and checks it according to implemented driver.
 	Verify() bool
 	// Active determines whether the ADC device is active.
@@ -51,6 +53,7 @@ type ADS1115 struct {
 
 	bias float64
 	convertor func(float64) float64
+	dataRate *ads.ConfigDataRate
 }
 
 // NewADC constructs a new ADC implementation via ADS1115 device driver.
@@ -78,6 +81,10 @@ func (d *ADS1115) Init() (err error) {
 		return errors.Wrapf(err, "failed to init ADS1115 device on '%s' bus and 0x%X address", d.Bus, d.Addr)
 	}
 
+	if d.dataRate != nil {
+		d.SetConfigDataRate(*d.dataRate)
+	}
+
 	d.active = true
 
 	return nil
@@ -133,6 +140,24 @@ func (d *ADS1115) Min(n int, t *time.Duration) float64 {
 	return d.convertor(float64(results[0])) - d.bias
 }
 
+func (d *ADS1115) Sequence(n int, t *time.Duration) ([]float64, time.Duration) {
+	d.Lock()
+	defer d.Unlock()
+
+	var (
+		start   = time.Now()
+		results = d.rawSequence(n, t)
+		elapsed = time.Since(start)
+		samples = make([]float64, len(results))
+	)
+
+	for i := range results {
+		samples[i] = d.convertor(float64(results[i])) - d.bias
+	}
+
+	return samples, elapsed
+}
+
 func (d ADS1115) rawSequence(n int, t *time.Duration) []int {
 	var (
 		i = n
@@ -1,6 +1,10 @@
 package periphery
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/MichaelS11/go-ads"
+)
 
 // An ADCOption configures a ADC driver.
 type ADCOption interface {
@@ -38,3 +42,11 @@ func WithI2CMutex(mutex *sync.Mutex) ADCOption {
 		d.Mutex = mutex
 	})
 }
+
+// WithDataRate can be used to specify ADC sampling data rate.
+// Default is the one set by the chip itself: 128 samples per second.
+func WithDataRate(rate ads.ConfigDataRate) ADCOption {
+	return ADCOptionFunc(func(d *ADS1115) {
+		d.dataRate = &rate
+	})
+}
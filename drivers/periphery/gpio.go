@@ -40,6 +40,26 @@ func (g *GPIO) Init() error {
 	return nil
 }
 
+// InitInput performs GPIO driver initialization as an input pin
+// with specified `pull` resistor and `edge` detection mode.
+func (g *GPIO) InitInput(pull gpio.Pull, edge gpio.Edge) error {
+	var (
+		pin = gpioreg.ByName(g.pin)
+	)
+
+	if pin == gpio.INVALID || pin == nil {
+		return errors.Errorf("pin %s is invalid", g.pin)
+	}
+
+	g.PinIO = pin
+
+	if err := g.In(pull, edge); err != nil {
+		return errors.Wrapf(err, "failed initialising %s pin as input", g.pin)
+	}
+
+	return nil
+}
+
 // High sends high level signal to GPIO pin.
 func (g *GPIO) High() error {
 	return g.Out(gpio.High)
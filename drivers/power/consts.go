@@ -5,6 +5,22 @@ const (
 	MAX17040_VOL_REG = 0x02
 	MAX17040_SOC_REG = 0x04
 	MAX17040_MOD_REG = 0x06
+	MAX17040_VER_REG = 0x08
+	MAX17040_CFG_REG = 0x0C
 	MAX17040_CMD_REG = 0xFE
-)
 
+	MAX17040_QUICK_START = 0x4000
+	MAX17040_POR_COMMAND = 0x5400
+
+	MAX17040_CFG_SLEEP_BIT = 0x80
+	MAX17040_CFG_ALERT_BIT = 0x20
+	MAX17040_CFG_ATHD_MASK = 0x1F
+
+	// MAX17040_VCELL_LSB defines cell voltage resolution in millivolts per 12-bit ADC unit.
+	MAX17040_VCELL_LSB = 1.25
+	// MAX17040_MAX_ALERT_THRESHOLD defines largest supported low state of charge alert threshold in percents.
+	MAX17040_MAX_ALERT_THRESHOLD = 32
+
+	UPS_PLUGGED_PIN = 4
+	UPS_I2C_BUS     = 1
+)
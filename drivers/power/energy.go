@@ -0,0 +1,58 @@
+package power
+
+import (
+	"sync"
+	"time"
+)
+
+// EnergyCounter integrates power consumption samples into consumed energy over time.
+type EnergyCounter struct {
+	mutex  *sync.Mutex
+	energy float64
+	since  time.Time
+	last   *powerSample
+}
+
+type powerSample struct {
+	power float64
+	time  time.Time
+}
+
+// NewEnergyCounter constructs new EnergyCounter instance.
+func NewEnergyCounter() *EnergyCounter {
+	return &EnergyCounter{
+		mutex: &sync.Mutex{},
+		since: time.Now(),
+	}
+}
+
+// Add adds power consumption sample in milliwatts taken at `at` time,
+// integrating energy since the previous sample with trapezoidal rule.
+func (c *EnergyCounter) Add(power float64, at time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.last != nil && at.After(c.last.time) {
+		c.energy += (c.last.power + power) / 2 * at.Sub(c.last.time).Seconds()
+	}
+
+	c.last = &powerSample{power: power, time: at}
+}
+
+// Take returns energy in millijoules consumed since the previous call along with the period it was consumed for,
+// and starts counting over. Returns false if there were not enough samples to integrate.
+func (c *EnergyCounter) Take(at time.Time) (float64, time.Duration, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var (
+		energy = c.energy
+		period = at.Sub(c.since)
+		ok     = energy > 0 && period > 0
+	)
+
+	c.energy = 0
+	c.since = at
+
+	return energy, period, ok
+}
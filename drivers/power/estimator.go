@@ -0,0 +1,108 @@
+package power
+
+import (
+	"sync"
+	"time"
+)
+
+// minEstimationSpan defines minimal time span of the samples required for the rate estimation,
+// since state of charge changes too slowly to be estimated over shorter periods.
+const minEstimationSpan = 5 * time.Minute
+
+// RateEstimator estimates battery charge or discharge rate from state of charge samples within sliding time window.
+type RateEstimator struct {
+	mutex   *sync.Mutex
+	window  time.Duration
+	samples []chargeSample
+}
+
+type chargeSample struct {
+	level float64
+	time  time.Time
+}
+
+// NewRateEstimator constructs new RateEstimator instance, which uses samples within `window` duration.
+func NewRateEstimator(window time.Duration) *RateEstimator {
+	return &RateEstimator{
+		mutex:  &sync.Mutex{},
+		window: window,
+	}
+}
+
+// Add adds state of charge `level` sample taken at `at` time, discarding samples outside the window.
+func (e *RateEstimator) Add(level float64, at time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.samples = append(e.samples, chargeSample{level: level, time: at})
+
+	var i = 0
+	for i < len(e.samples) && at.Sub(e.samples[i].time) > e.window {
+		i++
+	}
+
+	e.samples = e.samples[i:]
+}
+
+// Reset discards all samples, which is needed once the battery switches between charging and discharging.
+func (e *RateEstimator) Reset() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.samples = nil
+}
+
+// Rate estimates state of charge change rate in percents per hour with least squares fit of the samples,
+// where positive rate means charging and negative one means discharging.
+// Returns false if there are not enough samples for estimation.
+func (e *RateEstimator) Rate() (float64, bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if len(e.samples) < 2 || e.samples[len(e.samples)-1].time.Sub(e.samples[0].time) < minEstimationSpan {
+		return 0, false
+	}
+
+	var (
+		origin                   = e.samples[0].time
+		n                        = float64(len(e.samples))
+		sumX, sumY, sumXY, sumXX float64
+	)
+
+	for _, s := range e.samples {
+		x := s.time.Sub(origin).Hours()
+		sumX += x
+		sumY += s.level
+		sumXY += x * s.level
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return (n*sumXY - sumX*sumY) / denominator, true
+}
+
+// TimeToEmpty estimates time left until battery with current state of charge `level` is discharged.
+// Returns false if battery isn't discharging or rate cannot be estimated yet.
+func (e *RateEstimator) TimeToEmpty(level float64) (time.Duration, bool) {
+	rate, ok := e.Rate()
+	if !ok || rate >= 0 {
+		return 0, false
+	}
+
+	return time.Duration(level / -rate * float64(time.Hour)), true
+}
+
+// TimeToFull estimates time left until battery with current state of charge `level` is fully charged.
+// Returns false if battery isn't charging or rate cannot be estimated yet.
+func (e *RateEstimator) TimeToFull(level float64) (time.Duration, bool) {
+	rate, ok := e.Rate()
+	if !ok || rate <= 0 {
+		return 0, false
+	}
+
+	return time.Duration((100 - level) / rate * float64(time.Hour)), true
+}
@@ -0,0 +1,155 @@
+package power
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+)
+
+// MAX17040 defines driver for MAX17040/MAX17043 fuel-gauge chip.
+//
+// Low state of charge alert is supported by MAX17043 chip revision,
+// which asserts its ALRT pin and sets the alert bit of the config register once the threshold is reached.
+type MAX17040 struct {
+	*periphery.I2C
+}
+
+// FuelGaugeConfig defines content of the MAX17040 config register.
+type FuelGaugeConfig struct {
+	// RCOMP is a compensation value for the battery chemistry and temperature.
+	RCOMP byte
+	// Sleep determines whether the chip is in low-power sleep mode.
+	Sleep bool
+	// Alert determines whether state of charge dropped below the AlertThreshold.
+	Alert bool
+	// AlertThreshold is a state of charge percentage in range of [1-32%] at which alert is raised.
+	AlertThreshold int
+}
+
+// NewMAX17040 constructs new MAX17040 driver instance.
+func NewMAX17040(addr uint16, bus int) *MAX17040 {
+	return &MAX17040{
+		I2C: periphery.NewI2C(addr, bus),
+	}
+}
+
+// QuickStart restarts fuel-gauge calculations, which is useful when the battery was swapped or power-up was noisy.
+func (d *MAX17040) QuickStart() error {
+	if err := d.WriteRegU16BE(MAX17040_MOD_REG, MAX17040_QUICK_START); err != nil {
+		return errors.Wrap(err, "failed to quick-start MAX17040 fuel gauge")
+	}
+
+	return nil
+}
+
+// Reset performs power-on reset of the MAX17040 chip, restoring default config.
+func (d *MAX17040) Reset() error {
+	// Chip resets right away without acknowledging the command, so write error is expected here:
+	_ = d.WriteRegU16BE(MAX17040_CMD_REG, MAX17040_POR_COMMAND)
+
+	return nil
+}
+
+// StateOfCharge reads current battery state of charge in percents with 1/256% resolution.
+func (d *MAX17040) StateOfCharge() (float64, error) {
+	payload, err := d.ReadRegBytes(MAX17040_SOC_REG, 2)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read state of charge from MAX17040")
+	}
+
+	return float64(payload[0]) + float64(payload[1])/256, nil
+}
+
+// Voltage reads current battery cell voltage in millivolts.
+func (d *MAX17040) Voltage() (float64, error) {
+	raw, err := d.ReadRegU16BE(MAX17040_VOL_REG)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read cell voltage from MAX17040")
+	}
+
+	// Voltage is a 12-bit value stored in the upper bits of the register:
+	return float64(raw>>4) * MAX17040_VCELL_LSB, nil
+}
+
+// Version reads production version of the MAX17040 chip.
+func (d *MAX17040) Version() (uint16, error) {
+	version, err := d.ReadRegU16BE(MAX17040_VER_REG)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read version of MAX17040")
+	}
+
+	return version, nil
+}
+
+// Config reads content of the MAX17040 config register.
+func (d *MAX17040) Config() (FuelGaugeConfig, error) {
+	payload, err := d.ReadRegBytes(MAX17040_CFG_REG, 2)
+	if err != nil {
+		return FuelGaugeConfig{}, errors.Wrap(err, "failed to read config of MAX17040")
+	}
+
+	return FuelGaugeConfig{
+		RCOMP:          payload[0],
+		Sleep:          payload[1]&MAX17040_CFG_SLEEP_BIT != 0,
+		Alert:          payload[1]&MAX17040_CFG_ALERT_BIT != 0,
+		AlertThreshold: MAX17040_MAX_ALERT_THRESHOLD - int(payload[1]&MAX17040_CFG_ATHD_MASK),
+	}, nil
+}
+
+// SetConfig writes `config` to the MAX17040 config register.
+func (d *MAX17040) SetConfig(config FuelGaugeConfig) error {
+	if config.AlertThreshold < 1 || config.AlertThreshold > MAX17040_MAX_ALERT_THRESHOLD {
+		return errors.Errorf("alert threshold must be in range of [1-%d%%], got %d%%",
+			MAX17040_MAX_ALERT_THRESHOLD, config.AlertThreshold)
+	}
+
+	var lsb = byte(MAX17040_MAX_ALERT_THRESHOLD - config.AlertThreshold)
+
+	if config.Sleep {
+		lsb |= MAX17040_CFG_SLEEP_BIT
+	}
+
+	if config.Alert {
+		lsb |= MAX17040_CFG_ALERT_BIT
+	}
+
+	if err := d.WriteRegBytes(MAX17040_CFG_REG, config.RCOMP, lsb); err != nil {
+		return errors.Wrap(err, "failed to write config of MAX17040")
+	}
+
+	return nil
+}
+
+// SetAlertThreshold sets state of charge percentage in range of [1-32%] at which low battery alert is raised.
+func (d *MAX17040) SetAlertThreshold(percent int) error {
+	config, err := d.Config()
+	if err != nil {
+		return err
+	}
+
+	config.AlertThreshold = percent
+
+	return d.SetConfig(config)
+}
+
+// Alert determines whether the low state of charge alert was raised.
+func (d *MAX17040) Alert() (bool, error) {
+	config, err := d.Config()
+	if err != nil {
+		return false, err
+	}
+
+	return config.Alert, nil
+}
+
+// ClearAlert clears low state of charge alert, so that ALRT pin is released and alert could be raised again.
+func (d *MAX17040) ClearAlert() error {
+	config, err := d.Config()
+	if err != nil {
+		return err
+	}
+
+	config.Alert = false
+
+	return d.SetConfig(config)
+}
@@ -2,23 +2,38 @@ package power
 
 import (
 	"math"
+	"time"
 
 	"github.com/pkg/errors"
+	"periph.io/x/periph/conn/gpio"
+
 	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
 )
 
 // UPSController defines driver for UPS shield device with MAX17040 chip inside.
 type UPSController struct {
-	*periphery.I2C
-	pwrPin *periphery.GPIO
+	*MAX17040
+	pwrPin   *periphery.GPIO
+	alertPin *periphery.GPIO
+
+	alertThreshold int
 }
 
 // NewUPSController constructs new UPSController instance.
-func NewUPSController() *UPSController {
-	return &UPSController{
-		I2C:    periphery.NewI2C(MAX17040_ADDRESS, 1),
-		pwrPin: periphery.NewGPIO(4),
+// Low battery alert is raised at `alertThreshold` state of charge percentage,
+// and optionally signaled via fuel-gauge ALRT output connected to `alertPin`, zero means it isn't connected.
+func NewUPSController(alertThreshold int, alertPin int) *UPSController {
+	ups := &UPSController{
+		MAX17040:       NewMAX17040(MAX17040_ADDRESS, UPS_I2C_BUS),
+		pwrPin:         periphery.NewGPIO(UPS_PLUGGED_PIN),
+		alertThreshold: alertThreshold,
 	}
+
+	if alertPin != 0 {
+		ups.alertPin = periphery.NewGPIO(alertPin)
+	}
+
+	return ups
 }
 
 // Init performs initialization sequence of the UPSController.
@@ -31,14 +46,19 @@ func (ups *UPSController) Init() error {
 		return errors.Wrap(err, "failed to init GPIO periphery of UPS")
 	}
 
-	// enable quick-start mode
-	if err := ups.WriteRegBytes(MAX17040_MOD_REG, 0x40, 0x00); err != nil {
-		return errors.Wrap(err, "failed to set quick-start mode for USP")
+	// ALRT output is open-drain and active low:
+	if ups.alertPin != nil {
+		if err := ups.alertPin.InitInput(gpio.PullUp, gpio.FallingEdge); err != nil {
+			return errors.Wrap(err, "failed to init alert GPIO periphery of UPS")
+		}
 	}
 
-	// reset ups config
-	if err := ups.WriteRegBytes(MAX17040_CMD_REG, 0x00, 0x54); err != nil {
-		return errors.Wrap(err, "failed to set quick-start mode for USP")
+	if err := ups.QuickStart(); err != nil {
+		return errors.Wrap(err, "failed to set quick-start mode for UPS")
+	}
+
+	if err := ups.SetAlertThreshold(ups.alertThreshold); err != nil {
+		return errors.Wrap(err, "failed to set low battery alert threshold for UPS")
 	}
 
 	return nil
@@ -46,33 +66,40 @@ func (ups *UPSController) Init() error {
 
 // BatteryLevel reads current battery level in range of [0-100%].
 func (ups *UPSController) BatteryLevel() (int, error) {
-	payload, err := ups.ReadRegBytes(MAX17040_SOC_REG, 2)
+	soc, err := ups.StateOfCharge()
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to read battery level from UPS")
 	}
 
-	raw := float64(payload[0]) + (float64(payload[1]) / 256)
-
-	if raw > 100 {
-		raw = 100
-	}
-
-	return int(math.Round(raw)), nil
+	return int(math.Round(math.Min(soc, 100))), nil
 }
 
-// BatteryVoltage reads current battery voltage.
+// BatteryVoltage reads current battery voltage in millivolts.
 func (ups *UPSController) BatteryVoltage() (float64, error) {
-	payload, err := ups.ReadRegBytes(MAX17040_VOL_REG, 2)
+	voltage, err := ups.Voltage()
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to read battery voltage from UPS")
 	}
 
-	raw := (payload[0] << 8 + payload[1]) >> 4
-
-	return float64(raw), nil
+	return voltage, nil
 }
 
 // IsPlugged determines whether the UPS is plugged in and charging.
 func (ups *UPSController) IsPlugged() bool {
 	return ups.pwrPin.IsHigh()
 }
+
+// WaitForAlert waits for low battery alert signaled via ALRT pin for `timeout` duration.
+// Returns false right away if alert pin isn't connected, so status should be polled with Alert() instead.
+func (ups *UPSController) WaitForAlert(timeout time.Duration) bool {
+	if ups.alertPin == nil {
+		return false
+	}
+
+	return ups.alertPin.WaitForEdge(timeout)
+}
+
+// AlertPinConnected determines whether the fuel-gauge ALRT output is connected to GPIO pin.
+func (ups *UPSController) AlertPinConnected() bool {
+	return ups.alertPin != nil
+}
@@ -3,13 +3,16 @@ package sensors
 import (
 	"sync"
 
+	"github.com/MichaelS11/go-ads"
 	"github.com/spf13/viper"
 	"github.com/timoth-y/chainmetric-core/models"
 
 	"github.com/timoth-y/chainmetric-core/models/metrics"
 
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/core/spectrum"
 	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model"
 )
 
 var (
@@ -18,13 +21,26 @@ var (
 
 type ADCMic struct {
 	periphery.ADC
-	samples int
+	samples  int
+	spectrum spectrumAnalyzer
 }
 
 func NewADCMicrophone(addr uint16, bus int) sensor.Sensor {
+	var (
+		analyzer = newSpectrumAnalyzer("ADC_Microphone")
+		options  = []periphery.ADCOption{
+			periphery.WithI2CMutex(adcMicMutex),
+		}
+	)
+
+	if analyzer.Enabled {
+		options = append(options, periphery.WithDataRate(ads.ConfigDataRate860))
+	}
+
 	return &ADCMic{
-		ADC:     periphery.NewADC(addr, bus, periphery.WithI2CMutex(adcMicMutex)),
-		samples: viper.GetInt("sensors.analog.samples_per_read"),
+		ADC:      periphery.NewADC(addr, bus, options...),
+		samples:  viper.GetInt("sensors.analog.samples_per_read"),
+		spectrum: analyzer,
 	}
 }
 
@@ -33,16 +49,52 @@ func (s *ADCMic) ID() string {
 }
 
 func (s *ADCMic) Read() float64 {
-	return ADC_MICROPHONE_REGRESSION_C1 * (s.RMS(s.samples, nil) - ADC_MICROPHONE_BIAS) +
-		ADC_MICROPHONE_REGRESSION_C2
+	return s.toDecibels(s.RMS(s.samples, nil))
+}
+
+// ReadWeighted samples microphone signal and returns both flat and A-weighted sound levels.
+func (s *ADCMic) ReadWeighted() (level float64, weighted float64) {
+	var (
+		samples, elapsed = s.Sequence(s.spectrum.Samples, &s.spectrum.SampleInterval)
+		sp = s.spectrum.analyze(samples, sampleRate(len(samples), elapsed))
+		flat = sp.RMS()
+	)
+
+	level = s.toDecibels(spectrum.RootMeanSquare(samples))
+
+	if flat == 0 {
+		return level, level
+	}
+
+	// A-weighting is applied as a level correction between weighted and flat signal spectrum:
+	return level, level + spectrum.Decibels(sp.Weighted(spectrum.AWeighting).RMS() / flat)
 }
 
 func (s *ADCMic) Harvest(ctx *sensor.Context) {
-	ctx.WriterFor(metrics.NoiseLevel).Write(s.Read())
+	if !s.spectrum.requestedIn(ctx, model.NoiseLevelA) {
+		ctx.WriterFor(metrics.NoiseLevel).Write(s.Read())
+		return
+	}
+
+	level, weighted := s.ReadWeighted()
+
+	ctx.WriterFor(metrics.NoiseLevel).Write(level)
+	ctx.WriterFor(model.NoiseLevelA).Write(weighted)
 }
 
 func (s *ADCMic) Metrics() []models.Metric {
+	if s.spectrum.Enabled {
+		return []models.Metric {
+			metrics.NoiseLevel,
+			model.NoiseLevelA,
+		}
+	}
+
 	return []models.Metric {
 		metrics.NoiseLevel,
 	}
 }
+
+func (s *ADCMic) toDecibels(rms float64) float64 {
+	return ADC_MICROPHONE_REGRESSION_C1 * (rms - ADC_MICROPHONE_BIAS) + ADC_MICROPHONE_REGRESSION_C2
+}
@@ -3,14 +3,15 @@ package sensors
 import (
 	"sync"
 
+	"github.com/MichaelS11/go-ads"
 	"github.com/spf13/viper"
 	"github.com/timoth-y/chainmetric-core/models"
 
 	"github.com/timoth-y/chainmetric-core/models/metrics"
 
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/core/spectrum"
 	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
-	"github.com/timoth-y/chainmetric-iot/shared"
 )
 
 var (
@@ -19,18 +20,30 @@ var (
 
 type ADCPiezo struct {
 	periphery.ADC
-	samples int
+	samples  int
+	spectrum spectrumAnalyzer
 }
 
 func NewADCPiezo(addr uint16, bus int) sensor.Sensor {
+	var (
+		analyzer = newSpectrumAnalyzer("ADC_Piezo")
+		options  = []periphery.ADCOption{
+			periphery.WithConversion(func(raw float64) float64 {
+				volts := raw / periphery.ADS1115_SAMPLES_PER_READ * periphery.ADS1115_VOLTS_PER_SAMPLE
+				return volts
+			}),
+			periphery.WithI2CMutex(adcPiezoMutex),
+		}
+	)
+
+	if analyzer.Enabled {
+		options = append(options, periphery.WithDataRate(ads.ConfigDataRate860))
+	}
+
 	return &ADCPiezo{
-		ADC: periphery.NewADC(addr, bus, periphery.WithConversion(func(raw float64) float64 {
-			shared.Logger.Debug("ADC_Piezo", "-> raw =", raw)
-			volts := raw / periphery.ADS1115_SAMPLES_PER_READ * periphery.ADS1115_VOLTS_PER_SAMPLE
-			shared.Logger.Debug("ADC_Piezo", "-> volts =", volts)
-			return volts
-		}), periphery.WithI2CMutex(adcPiezoMutex)),
-		samples: viper.GetInt("sensors.analog.samples_per_read"),
+		ADC:      periphery.NewADC(addr, bus, options...),
+		samples:  viper.GetInt("sensors.analog.samples_per_read"),
+		spectrum: analyzer,
 	}
 }
 
@@ -43,11 +56,19 @@ func (s *ADCPiezo) Read() float64 {
 }
 
 func (s *ADCPiezo) Harvest(ctx *sensor.Context) {
-	ctx.WriterFor(metrics.Vibration).Write(s.Read())
+	if !s.spectrum.requestedIn(ctx, s.spectrum.vibrationMetrics()...) {
+		ctx.WriterFor(metrics.Vibration).Write(s.Read())
+		return
+	}
+
+	samples, elapsed := s.Sequence(s.spectrum.Samples, &s.spectrum.SampleInterval)
+
+	ctx.WriterFor(metrics.Vibration).Write(spectrum.RootMeanSquare(samples))
+	s.spectrum.harvestVibration(ctx, samples, sampleRate(len(samples), elapsed))
 }
 
 func (s *ADCPiezo) Metrics() []models.Metric {
-	return []models.Metric {
+	return append([]models.Metric {
 		metrics.Vibration,
-	}
+	}, s.spectrum.vibrationMetrics()...)
 }
@@ -3,6 +3,7 @@ package sensors
 import (
 	"math"
 	"sync"
+	"time"
 
 	"github.com/timoth-y/chainmetric-core/models"
 
@@ -25,11 +26,13 @@ const (
 // ADXL345 sensor device.
 type ADXL345 struct {
 	*periphery.I2C
+	spectrum spectrumAnalyzer
 }
 
 func NewADXL345(addr uint16, bus int) sensor.Sensor {
 	return &ADXL345{
-		I2C: periphery.NewI2C(addr, bus, periphery.WithMutex(adxl345Mutex)),
+		I2C:      periphery.NewI2C(addr, bus, periphery.WithMutex(adxl345Mutex)),
+		spectrum: newSpectrumAnalyzer("ADXL345"),
 	}
 }
 
@@ -42,12 +45,20 @@ func (s *ADXL345) Init() error {
 		return err
 	}
 
-	// changes the device bandwidth and output data rate
-	if err := s.WriteRegBytes(ADXL345_BW_RATE, ADXL345_Rate100HZ); err != nil {
+	// changes the device bandwidth and output data rate,
+	// which must be high enough for vibration spectrum analysis when it's enabled
+	var rate byte = ADXL345_Rate100HZ
+	if s.spectrum.Enabled {
+		rate = ADXL345_Rate800HZ
+	}
+
+	if err := s.WriteRegBytes(ADXL345_BW_RATE, rate); err != nil {
 		return err
 	}
 
-	if err := s.setRange(ADXL345_RANGE2G); err != nil {
+	// full resolution mode keeps the same scale factor on any range,
+	// so the widest one is used to capture shocks without clipping
+	if err := s.setRange(ADXL345_RANGE16G); err != nil {
 		return err
 	}
 
@@ -76,14 +87,34 @@ func (s *ADXL345) ReadAxes() (model.Vector, error) {
 	}, nil
 }
 
+// ReadMagnitudeSequence samples `n` acceleration magnitudes with given `interval`
+// and returns them along with actual sampling rate.
+func (s *ADXL345) ReadMagnitudeSequence(n int, interval time.Duration) ([]float64, float64, error) {
+	return sampleSequence(n, interval, func() (float64, error) {
+		return toMagnitude(s.ReadAxes())
+	})
+}
+
 func (s *ADXL345) Harvest(ctx *sensor.Context) {
 	ctx.WriterFor(metrics.Acceleration).WriteWithError(toMagnitude(s.ReadAxes()))
+
+	if !s.spectrum.requestedIn(ctx, s.spectrum.vibrationMetrics()...) {
+		return
+	}
+
+	samples, rate, err := s.ReadMagnitudeSequence(s.spectrum.Samples, s.spectrum.SampleInterval)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	s.spectrum.harvestVibration(ctx, samples, rate)
 }
 
 func (s *ADXL345) Metrics() []models.Metric {
-	return []models.Metric{
+	return append([]models.Metric{
 		metrics.Acceleration,
-	}
+	}, s.spectrum.vibrationMetrics()...)
 }
 
 func (s *ADXL345) Verify() bool {
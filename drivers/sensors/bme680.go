@@ -0,0 +1,289 @@
+package sensors
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"periph.io/x/periph/conn/physic"
+
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+)
+
+var (
+	bme680GasRangeK1 = [16]float64{0, 0, 0, 0, 0, -1, 0, -0.8, 0, 0, -0.2, -0.5, 0, -1, 0, 0}
+	bme680GasRangeK2 = [16]float64{0, 0, 0, 0, 0.1, 0.7, 0, -0.8, -0.1, 0, 0, 0, 0, 0, 0, 0}
+)
+
+// bme680 implements BME680 environmental sensor protocol in forced mode,
+// based on floating point compensation formulas from the Bosch Sensortec reference driver.
+type bme680 struct {
+	*periphery.I2C
+	config config.BMXX80Config
+	cal    bme680Calibration
+}
+
+// bme680Calibration contains factory calibration parameters of the BME680 sensor.
+type bme680Calibration struct {
+	t1, t2, t3                              float64
+	p1, p2, p3, p4, p5, p6, p7, p8, p9, p10 float64
+	h1, h2, h3, h4, h5, h6, h7              float64
+	gh1, gh2, gh3                           float64
+	resHeatRange, resHeatVal, rangeSwErr    float64
+}
+
+// bme680Readings contains compensated BME680 measurement results.
+type bme680Readings struct {
+	physic.Env
+	// GasResistance is a gas sensor resistance in Ohm.
+	GasResistance float64
+	// GasValid determines whether the gas measurement is valid and hot plate has reached target temperature.
+	GasValid bool
+}
+
+func newBME680(i2c *periphery.I2C, config config.BMXX80Config) *bme680 {
+	return &bme680{
+		I2C:    i2c,
+		config: config,
+	}
+}
+
+// init performs soft reset of the sensor and reads its calibration parameters.
+func (d *bme680) init() error {
+	if err := d.WriteRegBytes(BME680_RESET, BME680_SOFT_RESET); err != nil {
+		return errors.Wrap(err, "failed to reset BME680")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	coeff1, err := d.ReadRegBytes(BME680_COEFF1, BME680_COEFF1_LEN)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BME680 calibration data")
+	}
+
+	coeff2, err := d.ReadRegBytes(BME680_COEFF2, BME680_COEFF2_LEN)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BME680 calibration data")
+	}
+
+	heatVal, err := d.ReadReg(BME680_RES_HEAT_VAL)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BME680 heater calibration")
+	}
+
+	heatRange, err := d.ReadReg(BME680_RES_HEAT_RANGE)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BME680 heater calibration")
+	}
+
+	swErr, err := d.ReadReg(BME680_RANGE_SW_ERR)
+	if err != nil {
+		return errors.Wrap(err, "failed to read BME680 heater calibration")
+	}
+
+	var (
+		c   = append(coeff1, coeff2...)
+		u16 = func(msb, lsb int) float64 { return float64(uint16(c[msb])<<8 | uint16(c[lsb])) }
+		s16 = func(msb, lsb int) float64 { return float64(int16(uint16(c[msb])<<8 | uint16(c[lsb]))) }
+		s8  = func(i int) float64 { return float64(int8(c[i])) }
+	)
+
+	d.cal = bme680Calibration{
+		t1: u16(34, 33), t2: s16(2, 1), t3: s8(3),
+		p1: u16(6, 5), p2: s16(8, 7), p3: s8(9), p4: s16(12, 11), p5: s16(14, 13),
+		p6: s8(16), p7: s8(15), p8: s16(20, 19), p9: s16(22, 21), p10: float64(c[23]),
+		h1: float64(uint16(c[27])<<4 | uint16(c[26]&0x0F)),
+		h2: float64(uint16(c[25])<<4 | uint16(c[26]>>4)),
+		h3: s8(28), h4: s8(29), h5: s8(30), h6: float64(c[31]), h7: s8(32),
+		gh1: s8(37), gh2: s16(36, 35), gh3: s8(38),
+		resHeatRange: float64((heatRange & 0x30) >> 4),
+		resHeatVal:   float64(int8(heatVal)),
+		rangeSwErr:   float64(int8(swErr&0xF0) >> 4),
+	}
+
+	return d.WriteRegBytes(BME680_CONFIG, filterCode(d.config.Filter)<<2)
+}
+
+// sense performs single measurement in forced mode.
+// Ambient temperature `ambient` (°C) is used to calculate gas sensor heater resistance.
+func (d *bme680) sense(ambient float64) (bme680Readings, error) {
+	var (
+		ctrlHum  = oversamplingCode(d.config.HumidityOversampling)
+		ctrlMeas = oversamplingCode(d.config.TemperatureOversampling)<<5 |
+			oversamplingCode(d.config.PressureOversampling)<<2
+		readings bme680Readings
+	)
+
+	if err := d.WriteRegBytes(BME680_RES_HEAT0, d.heaterResistance(ambient)); err != nil {
+		return readings, err
+	}
+
+	if err := d.WriteRegBytes(BME680_GAS_WAIT0, heaterDurationCode(d.config.HeaterDuration)); err != nil {
+		return readings, err
+	}
+
+	if err := d.WriteRegBytes(BME680_CTRL_GAS1, BME680_RUN_GAS); err != nil {
+		return readings, err
+	}
+
+	if err := d.WriteRegBytes(BME680_CTRL_HUM, ctrlHum); err != nil {
+		return readings, err
+	}
+
+	if err := d.WriteRegBytes(BME680_CTRL_MEAS, ctrlMeas|BME680_FORCED_MODE); err != nil {
+		return readings, err
+	}
+
+	time.Sleep(d.config.HeaterDuration)
+
+	for attempt := 0; attempt < BME680_POLL_ATTEMPTS; attempt++ {
+		field, err := d.ReadRegBytes(BME680_FIELD0, BME680_FIELD_LEN)
+		if err != nil {
+			return readings, err
+		}
+
+		if field[0]&BME680_NEW_DATA == 0 {
+			time.Sleep(BME680_POLL_INTERVAL)
+			continue
+		}
+
+		var (
+			pressADC = float64(uint32(field[2])<<12 | uint32(field[3])<<4 | uint32(field[4])>>4)
+			tempADC  = float64(uint32(field[5])<<12 | uint32(field[6])<<4 | uint32(field[7])>>4)
+			humADC   = float64(uint16(field[8])<<8 | uint16(field[9]))
+			gasADC   = float64(uint16(field[13])<<2 | uint16(field[14])>>6)
+			gasRange = field[14] & 0x0F
+		)
+
+		tFine, temperature := d.compensateTemperature(tempADC)
+
+		readings.Temperature = physic.ZeroCelsius + physic.Temperature(temperature*float64(physic.Celsius))
+		readings.Pressure = physic.Pressure(d.compensatePressure(pressADC, tFine) * float64(physic.Pascal))
+		readings.Humidity = physic.RelativeHumidity(d.compensateHumidity(humADC, temperature) * float64(physic.PercentRH))
+		readings.GasResistance = d.compensateGasResistance(gasADC, gasRange)
+		readings.GasValid = field[14]&BME680_GAS_VALID != 0 && field[14]&BME680_HEAT_STAB != 0
+
+		return readings, nil
+	}
+
+	return readings, errors.New("BME680 measurement timed out")
+}
+
+func (d *bme680) compensateTemperature(adc float64) (tFine, temperature float64) {
+	var (
+		c    = d.cal
+		var1 = (adc/16384 - c.t1/1024) * c.t2
+		var2 = (adc/131072 - c.t1/8192) * (adc/131072 - c.t1/8192) * (c.t3 * 16)
+	)
+
+	tFine = var1 + var2
+
+	return tFine, tFine / 5120
+}
+
+func (d *bme680) compensatePressure(adc, tFine float64) float64 {
+	var (
+		c    = d.cal
+		var1 = tFine/2 - 64000
+		var2 = var1 * var1 * (c.p6 / 131072)
+	)
+
+	var2 = var2 + var1*c.p5*2
+	var2 = var2/4 + c.p4*65536
+	var1 = (c.p3*var1*var1/16384 + c.p2*var1) / 524288
+	var1 = (1 + var1/32768) * c.p1
+
+	if var1 == 0 {
+		return 0
+	}
+
+	pressure := (1048576 - adc - var2/4096) * 6250 / var1
+	var1 = c.p9 * pressure * pressure / 2147483648
+	var2 = pressure * (c.p8 / 32768)
+	var3 := math.Pow(pressure/256, 3) * (c.p10 / 131072)
+
+	return pressure + (var1+var2+var3+c.p7*128)/16
+}
+
+func (d *bme680) compensateHumidity(adc, temperature float64) float64 {
+	var (
+		c    = d.cal
+		var1 = adc - (c.h1*16 + c.h3/2*temperature)
+		var2 = var1 * (c.h2 / 262144 * (1 + c.h4/16384*temperature + c.h5/1048576*temperature*temperature))
+		var3 = c.h6 / 16384
+		var4 = c.h7 / 2097152
+	)
+
+	return math.Max(0, math.Min(100, var2+(var3+var4*temperature)*var2*var2))
+}
+
+func (d *bme680) compensateGasResistance(adc float64, gasRange byte) float64 {
+	var (
+		var1 = 1340 + 5*d.cal.rangeSwErr
+		var2 = var1 * (1 + bme680GasRangeK1[gasRange]/100)
+		var3 = 1 + bme680GasRangeK2[gasRange]/100
+	)
+
+	return 1 / (var3 * 0.000000125 * float64(uint32(1)<<gasRange) * ((adc-512)/var2 + 1))
+}
+
+// heaterResistance calculates heater resistance register value to reach configured hot plate temperature.
+func (d *bme680) heaterResistance(ambient float64) byte {
+	var (
+		c      = d.cal
+		target = math.Min(float64(d.config.HeaterTemperature), 400)
+		var1   = c.gh1/16 + 49
+		var2   = c.gh2/32768*0.0005 + 0.00235
+		var3   = c.gh3 / 1024
+		var4   = var1 * (1 + var2*target)
+		var5   = var4 + var3*ambient
+	)
+
+	return byte(3.4 * (var5*(4/(4+c.resHeatRange))*(1/(1+c.resHeatVal*0.002)) - 25))
+}
+
+// heaterDurationCode encodes heating duration into the gas_wait register format.
+func heaterDurationCode(d time.Duration) byte {
+	ms := d.Milliseconds()
+	if ms >= 0xFC0 {
+		return 0xFF
+	}
+
+	var factor byte
+	for ms > 0x3F {
+		ms /= 4
+		factor++
+	}
+
+	return byte(ms) + factor*64
+}
+
+// oversamplingCode encodes oversampling multiplier into register value format.
+func oversamplingCode(n int) byte {
+	switch {
+	case n <= 0:
+		return 0
+	case n < 2:
+		return 1
+	case n < 4:
+		return 2
+	case n < 8:
+		return 3
+	case n < 16:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// filterCode encodes IIR filter coefficient into register value format.
+func filterCode(n int) byte {
+	var code byte
+	for n > 1 && code < 7 {
+		n /= 2
+		code++
+	}
+
+	return code
+}
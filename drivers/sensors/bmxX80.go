@@ -1,9 +1,11 @@
 package sensors
 
 import (
+	"fmt"
 	"math"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/timoth-y/chainmetric-core/models"
 	"periph.io/x/periph/conn/physic"
 	"periph.io/x/periph/devices/bmxx80"
@@ -12,40 +14,110 @@ import (
 
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
 	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/shared"
 )
 
 var (
-	bmp280Mutex = &sync.Mutex{}
+	bmxx80Mutex    = &sync.Mutex{}
+	bmxx80AltMutex = &sync.Mutex{}
 )
 
-type BMP280 struct {
+// BMXX80 defines Bosch environmental sensor device, which can be either BMP280, BME280, or BME680.
+// Exact chip is determined by its ID during verification.
+type BMXX80 struct {
 	*periphery.I2C
 	*bmxx80.Dev
+	bme680 *bme680
+
+	name   string
+	chipID byte
+	config config.BMXX80Config
+	iaq    *iaqEstimator
+}
+
+// NewBMP280 constructs BMXX80 sensor driver for BMP280 pressure and temperature sensor.
+func NewBMP280(addr uint16, bus int) sensor.Sensor {
+	return newBMXX80("BMP280", BMP280_DEVICE_ID, addr, bus)
+}
+
+// NewBME280 constructs BMXX80 sensor driver for BME280 pressure, temperature, and humidity sensor.
+func NewBME280(addr uint16, bus int) sensor.Sensor {
+	return newBMXX80("BME280", BME280_DEVICE_ID, addr, bus)
+}
+
+// NewBME680 constructs BMXX80 sensor driver for BME680 pressure, temperature, humidity, and gas sensor.
+func NewBME680(addr uint16, bus int) sensor.Sensor {
+	return newBMXX80("BME680", BME680_DEVICE_ID, addr, bus)
 }
 
-func NewBMXX80(addr uint16, bus int) sensor.Sensor {
-	return &BMP280{
-		I2C: periphery.NewI2C(addr, bus, periphery.WithMutex(bmp280Mutex)),
+func newBMXX80(name string, chipID byte, addr uint16, bus int) *BMXX80 {
+	var (
+		mutex = bmxx80Mutex
+		cfg   = config.BMXX80Config{}
+	)
+
+	if addr == BMXX80_ALT_ADDRESS {
+		mutex = bmxx80AltMutex
+	}
+
+	if err := shared.UnmarshalFromConfig("sensors.bmxx80", &cfg); err != nil {
+		shared.Logger.Error(errors.Wrapf(err, "failed to parse config for '%s' sensor", name))
+	}
+
+	return &BMXX80{
+		I2C:    periphery.NewI2C(addr, bus, periphery.WithMutex(mutex)),
+		name:   name,
+		chipID: chipID,
+		config: cfg,
+		iaq:    &iaqEstimator{},
 	}
 }
 
-func (s *BMP280) ID() string {
-	return "BMP280"
+func (s *BMXX80) ID() string {
+	// Both addresses can be occupied on the same bus, so IDs must differ:
+	if s.Addr == BMXX80_ALT_ADDRESS {
+		return fmt.Sprintf("%s-ALT", s.name)
+	}
+
+	return s.name
 }
 
-func (s *BMP280) Init() (err error) {
+func (s *BMXX80) Init() (err error) {
 	if err = s.I2C.Init(); err != nil {
 		return
 	}
 
-	if s.Dev, err = bmxx80.NewI2C(s.Bus, s.Addr, &bmxx80.DefaultOpts); err != nil {
+	if s.chipID == BME680_DEVICE_ID {
+		s.bme680 = newBME680(s.I2C, s.config)
+		return s.bme680.init()
+	}
+
+	if s.Dev, err = bmxx80.NewI2C(s.Bus, s.Addr, &bmxx80.Opts{
+		Temperature: bmxx80.Oversampling(oversamplingCode(s.config.TemperatureOversampling)),
+		Pressure:    bmxx80.Oversampling(oversamplingCode(s.config.PressureOversampling)),
+		Humidity:    bmxx80.Oversampling(oversamplingCode(s.config.HumidityOversampling)),
+	}); err != nil {
 		return
 	}
 
-	return
+	// Driver resets IIR filter on initialization, though it is still applied in the forced mode,
+	// so the config register is overwritten here (standby time bits are ignored in the forced mode).
+	filter := filterCode(s.config.Filter)
+	if filter > byte(bmxx80.F16) {
+		filter = byte(bmxx80.F16)
+	}
+
+	return s.WriteRegBytes(BMX280_CONFIG, filter<<2)
 }
 
-func (s *BMP280) Harvest(ctx *sensor.Context) {
+func (s *BMXX80) Harvest(ctx *sensor.Context) {
+	if s.bme680 != nil {
+		s.harvestBME680(ctx)
+		return
+	}
+
 	s.Lock()
 	defer s.Unlock()
 
@@ -57,47 +129,131 @@ func (s *BMP280) Harvest(ctx *sensor.Context) {
 		return
 	}
 
+	s.writeEnv(ctx, env)
+}
+
+func (s *BMXX80) harvestBME680(ctx *sensor.Context) {
+	readings, err := s.bme680.sense(s.iaq.ambientTemperature())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	s.writeEnv(ctx, readings.Env)
+
+	if !readings.GasValid {
+		ctx.WriterFor(model.GasResistance).WriteWithError(0, errors.New("gas measurement is not valid"))
+		ctx.WriterFor(model.AirQualityIndex).WriteWithError(0, errors.New("gas measurement is not valid"))
+		return
+	}
+
+	ctx.WriterFor(model.GasResistance).Write(readings.GasResistance)
+	ctx.WriterFor(model.AirQualityIndex).WriteWithError(s.iaq.estimate(readings))
+}
+
+func (s *BMXX80) writeEnv(ctx *sensor.Context, env physic.Env) {
 	ctx.WriterFor(metrics.Pressure).Write(float64(env.Pressure))
-	ctx.WriterFor(metrics.Altitude).Write(s.pressureToAltitude(float64(env.Pressure)))
+	ctx.WriterFor(metrics.Altitude).Write(s.pressureToAltitude(env.Pressure))
 	ctx.WriterFor(metrics.Temperature).Write(env.Temperature.Celsius())
-	// ctx.WriterFor(metrics.Humidity).Write(float64(env.Humidity)) TODO: test compatibility
+
+	if s.chipID != BMP280_DEVICE_ID {
+		ctx.WriterFor(metrics.Humidity).Write(float64(env.Humidity) / float64(physic.PercentRH))
+	}
 }
 
-func (s *BMP280) Metrics() []models.Metric {
-	return []models.Metric {
+func (s *BMXX80) Metrics() []models.Metric {
+	ms := []models.Metric{
 		metrics.Pressure,
 		metrics.Altitude,
 		metrics.Temperature,
-		metrics.Humidity,
 	}
+
+	switch s.chipID {
+	case BME280_DEVICE_ID:
+		ms = append(ms, metrics.Humidity)
+	case BME680_DEVICE_ID:
+		ms = append(ms, metrics.Humidity, model.GasResistance, model.AirQualityIndex)
+	}
+
+	return ms
 }
 
-func (s *BMP280) Verify() bool {
+func (s *BMXX80) Verify() bool {
 	if !s.I2C.Verify() {
 		return false
 	}
 
-	if devID, err := s.I2C.ReadReg(BMP280_DEVICE_ID_REGISTER); err == nil {
-		return devID == BMP280_DEVICE_ID
+	if devID, err := s.I2C.ReadReg(BMXX80_DEVICE_ID_REGISTER); err == nil {
+		return devID == s.chipID
 	}
 
 	return false
 }
 
-func (s *BMP280) Active() bool {
-	return s.I2C.Active() && s.Dev != nil
+func (s *BMXX80) Active() bool {
+	return s.I2C.Active() && (s.Dev != nil || s.bme680 != nil)
 }
 
-func (s *BMP280) Close() error {
+func (s *BMXX80) Close() error {
 	s.Dev = nil
+	s.bme680 = nil
 	return s.I2C.Close()
 }
 
-func (s *BMP280) pressureToAltitude(p float64) float64 {
+func (s *BMXX80) pressureToAltitude(p physic.Pressure) float64 {
 	// Approximate atmospheric pressure at sea level in Pa
-	p0 := 1013250.0
-	a := 44330 * (1 - math.Pow(p / p0, 1/5.255))
+	p0 := 101325.0
+	a := 44330 * (1 - math.Pow(float64(p) / float64(physic.Pascal) / p0, 1/5.255))
 	// Round up to 2 decimals after point
 	a2 := float64(int(a*100)) / 100
 	return a2
 }
+
+// iaqEstimator approximates indoor air quality index based on gas resistance and humidity,
+// where gas resistance baseline is determined as the highest resistance observed after burn-in,
+// since lower resistance corresponds to higher concentration of the volatile organic compounds.
+type iaqEstimator struct {
+	samples     int
+	baseline    float64
+	temperature float64
+}
+
+// estimate returns IAQ index in range from 0 (excellent) to 500 (extremely polluted).
+func (e *iaqEstimator) estimate(readings bme680Readings) (float64, error) {
+	e.samples++
+	e.temperature = readings.Temperature.Celsius()
+
+	if e.samples <= IAQ_BURN_IN_SAMPLES {
+		return 0, errors.Errorf("gas sensor burn-in is in progress (%d/%d)", e.samples, IAQ_BURN_IN_SAMPLES)
+	}
+
+	e.baseline = math.Max(e.baseline, readings.GasResistance)
+
+	var (
+		humidity  = float64(readings.Humidity) / float64(physic.PercentRH)
+		humOffset = humidity - IAQ_HUMIDITY_BASELINE
+		humScore  float64
+		gasScore  = 1 - IAQ_HUMIDITY_WEIGHT
+	)
+
+	if humOffset > 0 {
+		humScore = (100 - IAQ_HUMIDITY_BASELINE - humOffset) / (100 - IAQ_HUMIDITY_BASELINE) * IAQ_HUMIDITY_WEIGHT
+	} else {
+		humScore = (IAQ_HUMIDITY_BASELINE + humOffset) / IAQ_HUMIDITY_BASELINE * IAQ_HUMIDITY_WEIGHT
+	}
+
+	if readings.GasResistance < e.baseline {
+		gasScore *= readings.GasResistance / e.baseline
+	}
+
+	return math.Round((1 - humScore - gasScore) * 500), nil
+}
+
+// ambientTemperature returns last measured temperature used for heater resistance calculation.
+func (e *iaqEstimator) ambientTemperature() float64 {
+	if e.samples == 0 {
+		return 25
+	}
+
+	return e.temperature
+}
@@ -1,14 +1,21 @@
 package sensors
 
+import (
+	"time"
+)
+
 const (
 	ADXL345_ADDRESS        = 0x53
-	BMP280_ADDRESS         = 0x76
+	BMXX80_ADDRESS         = 0x76
+	BMXX80_ALT_ADDRESS     = 0x77
 	CCS811_ADDRESS         = 0x5A
 	HDC1080_ADDRESS        = 0x40
 	MAX30102_ADDRESS       = 0x57
 	MAX44009_ADDRESS       = 0x4A
 	MAX44009_ALT_ADDRESS   = 0x4B
 	SI1145_ADDRESS         = 0x60
+	SCD30_ADDRESS          = 0x61
+	SCD4X_ADDRESS          = 0x62
 	LSM303C_A_ADDRESS      = 0x1D
 	LSM303C_M_ADDRESS      = 0x1E
 	ADC_HALL_ADDRESS       = 0x48
@@ -17,6 +24,9 @@ const (
 	ADC_PIEZO_ADDRESS      = 0x4B
 	ADC_FLAME_ADDRESS      = 0x4E
 	INA219_ADDRESS         = 0x44
+	INA219_ALT_ADDRESS     = 0x45
+	INA219_GND_ADDRESS     = 0x40
+	INA219_VS_ADDRESS      = 0x41
 	MOCK_ADDRESS           = 0x88
 )
 
@@ -50,6 +60,11 @@ const (
 	ADC_PIEZO_BIAS = 0
 )
 
+// Spectrum analysis constants
+const (
+	SPECTRUM_DEFAULT_SAMPLES = 256
+)
+
 // ADXL345 accelerometer sensor constants
 const (
 	// Registers
@@ -86,10 +101,87 @@ const (
 	ADXL345_DATAZ1 = 0x37
 )
 
-// BMP280 barometer sensor constants
+// BMP280, BME280, and BME680 environmental sensors constants
 const (
-	BMP280_DEVICE_ID_REGISTER = 0xD0
-	BMP280_DEVICE_ID = 0x60
+	BMXX80_DEVICE_ID_REGISTER = 0xD0
+
+	BMP280_DEVICE_ID = 0x58
+	BME280_DEVICE_ID = 0x60
+	BME680_DEVICE_ID = 0x61
+
+	// BMP280 and BME280 registers
+	BMX280_CONFIG = 0xF5
+
+	// BME680 registers
+	BME680_RESET          = 0xE0
+	BME680_COEFF1         = 0x89
+	BME680_COEFF2         = 0xE1
+	BME680_RES_HEAT_VAL   = 0x00
+	BME680_RES_HEAT_RANGE = 0x02
+	BME680_RANGE_SW_ERR   = 0x04
+	BME680_FIELD0         = 0x1D
+	BME680_RES_HEAT0      = 0x5A
+	BME680_GAS_WAIT0      = 0x64
+	BME680_CTRL_GAS1      = 0x71
+	BME680_CTRL_HUM       = 0x72
+	BME680_CTRL_MEAS      = 0x74
+	BME680_CONFIG         = 0x75
+
+	// BME680 constants
+	BME680_SOFT_RESET     = 0xB6
+	BME680_COEFF1_LEN     = 25
+	BME680_COEFF2_LEN     = 16
+	BME680_FIELD_LEN      = 15
+	BME680_RUN_GAS        = 0x10
+	BME680_FORCED_MODE    = 0x01
+	BME680_NEW_DATA       = 0x80
+	BME680_GAS_VALID      = 0x20
+	BME680_HEAT_STAB      = 0x10
+	BME680_POLL_INTERVAL  = 10 * time.Millisecond
+	BME680_POLL_ATTEMPTS  = 50
+
+	// IAQ estimation constants
+	IAQ_HUMIDITY_BASELINE = 40.0
+	IAQ_HUMIDITY_WEIGHT   = 0.25
+	IAQ_BURN_IN_SAMPLES   = 10
+)
+
+// Sensirion SCD30 and SCD4x NDIR CO2 sensors constants
+const (
+	SENSIRION_CRC_INIT       = 0xFF
+	SENSIRION_CRC_POLYNOMIAL = 0x31
+
+	// SCD30 commands
+	SCD30_START_CONTINUOUS      = 0x0010
+	SCD30_STOP_CONTINUOUS       = 0x0104
+	SCD30_MEASUREMENT_INTERVAL  = 0x4600
+	SCD30_DATA_READY            = 0x0202
+	SCD30_READ_MEASUREMENT      = 0x0300
+	SCD30_AUTO_SELF_CALIBRATION = 0x5306
+	SCD30_FORCED_RECALIBRATION  = 0x5204
+	SCD30_FIRMWARE_VERSION      = 0xD100
+
+	// SCD4x commands
+	SCD4X_START_PERIODIC        = 0x21B1
+	SCD4X_STOP_PERIODIC         = 0x3F86
+	SCD4X_READ_MEASUREMENT      = 0xEC05
+	SCD4X_DATA_READY            = 0xE4B8
+	SCD4X_AUTO_SELF_CALIBRATION = 0x2416
+	SCD4X_FORCED_RECALIBRATION  = 0x362F
+	SCD4X_AMBIENT_PRESSURE      = 0xE000
+
+	// Timings
+	SCD30_COMMAND_DELAY         = 3 * time.Millisecond
+	SCD4X_COMMAND_DELAY         = 1 * time.Millisecond
+	SCD4X_STOP_DELAY            = 500 * time.Millisecond
+	SCD4X_RECALIBRATION_DELAY   = 400 * time.Millisecond
+	NDIR_DATA_POLL_INTERVAL     = 100 * time.Millisecond
+	SCD30_RECALIBRATION_WARM_UP = 2 * time.Minute
+	SCD4X_RECALIBRATION_WARM_UP = 3 * time.Minute
+
+	// Constants
+	SCD4X_DATA_READY_MASK       = 0x07FF
+	SCD4X_RECALIBRATION_FAILED  = 0xFFFF
 )
 
 // CCS811 air quality sensor constants
@@ -175,6 +267,8 @@ const (
 	// Constants
 	LSM303C_A_DEVICE_ID = 0x41
 	LSM303C_M_DEVICE_ID = 0x3D
+
+	LSM303C_M_CALIBRATION_INTERVAL = 50 * time.Millisecond
 )
 
 // MAX30102 pulse-oximeter sensor constants
@@ -320,8 +414,12 @@ const(
 
 // INA219 current sensor constants
 const (
-	INA219_DEVICE_ID_REGISTER = 0x0F
-	INA219_DEVICE_ID          = 0x7C
+	INA219_CONFIG_REGISTER      = 0x00
+	INA219_CALIBRATION_REGISTER = 0x05
+	// INA219_CONFIG_RESERVED_BIT is unused bit of the config register, which always reads as 0.
+	INA219_CONFIG_RESERVED_BIT = 0x4000
+	// INA219_CALIBRATION_VOID_BIT is the lowest bit of the calibration register, which always reads as 0.
+	INA219_CALIBRATION_VOID_BIT = 0x0001
 )
 
 // I2CSensorMock sensor constants
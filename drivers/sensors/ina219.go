@@ -1,26 +1,45 @@
 package sensors
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
 	"github.com/timoth-y/chainmetric-core/models"
+	"periph.io/x/periph/conn/physic"
 	"periph.io/x/periph/experimental/devices/ina219"
 
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
 	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model"
+)
+
+var (
+	ina219Mutex = &sync.Mutex{}
 )
 
+// INA219 defines driver for INA219 current and power monitor,
+// which is used for measuring external power rails, e.g. of cooling unit, or device's own consumption.
 type INA219 struct {
 	*periphery.I2C
-	*ina219.Dev
+	dev *ina219.Dev
 }
 
-func NewINA219(addr uint16, bus int) *INA219 {
+// NewINA219 constructs new INA219 sensor driver instance.
+func NewINA219(addr uint16, bus int) sensor.Sensor {
 	return &INA219{
-		I2C: periphery.NewI2C(addr, bus),
+		I2C: periphery.NewI2C(addr, bus, periphery.WithMutex(ina219Mutex)),
 	}
 }
 
+// ID returns unique identifier of the INA219 sensor.
+// Several monitors can be connected on different addresses, so all except the default one are distinguished by it.
 func (s *INA219) ID() string {
-	return "INA219"
+	if s.Addr == INA219_ADDRESS {
+		return "INA219"
+	}
+
+	return fmt.Sprintf("INA219_%X", s.Addr)
 }
 
 func (s *INA219) Init() (err error) {
@@ -28,57 +47,74 @@ func (s *INA219) Init() (err error) {
 		return
 	}
 
-	if s.Dev, err = ina219.New(s.Bus, &ina219.Opts{
+	if s.dev, err = ina219.New(s.Bus, &ina219.Opts{
 		Address:       int(s.Addr),
 		SenseResistor: ina219.DefaultOpts.SenseResistor,
 		MaxCurrent:    ina219.DefaultOpts.MaxCurrent,
 	}); err != nil {
-		return
+		return errors.Wrap(err, "failed to initialize INA219 power monitor")
 	}
 
 	return
 }
 
-func (s *INA219) Harvest(ctx *sensor.Context) {
-	if power, err := s.Sense(); err != nil {
-		ctx.Error(err)
-	} else {
-		ctx.WriterFor("current").Write(float64(power.Current))
-		ctx.WriterFor("voltage").Write(float64(power.Voltage))
+// ReadPower reads voltage, current and power of the monitored rail.
+func (s *INA219) ReadPower() (model.PowerReading, error) {
+	if s.dev == nil {
+		return model.PowerReading{}, errors.New("INA219 power monitor is not initialized")
 	}
-}
 
-func (s *INA219) ReadVoltage() (int64, error) {
-	if power, err := s.Sense(); err != nil {
-		return 0, err
-	} else {
-		return int64(power.Voltage), nil
+	s.Lock()
+	defer s.Unlock()
+
+	pm, err := s.dev.Sense()
+	if err != nil {
+		return model.PowerReading{}, errors.Wrap(err, "failed to read from INA219 power monitor")
 	}
+
+	return model.PowerReading{
+		Voltage: float64(pm.Voltage) / float64(physic.Volt),
+		Current: float64(pm.Current) / float64(physic.MilliAmpere),
+		Power:   float64(pm.Power) / float64(physic.MilliWatt),
+	}, nil
 }
 
-func (s *INA219) ReadCurrent() (int64, error) {
-	if power, err := s.Sense(); err != nil {
-		return 0, err
-	} else {
-		return int64(power.Current), nil
+func (s *INA219) Harvest(ctx *sensor.Context) {
+	reading, err := s.ReadPower()
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
+
+	ctx.WriterFor(model.RailVoltage).Write(reading.Voltage)
+	ctx.WriterFor(model.RailCurrent).Write(reading.Current)
+	ctx.WriterFor(model.RailPower).Write(reading.Power)
 }
 
 func (s *INA219) Metrics() []models.Metric {
-	return []models.Metric {
-		"current",
-		"voltage",
+	return []models.Metric{
+		model.RailVoltage,
+		model.RailCurrent,
+		model.RailPower,
 	}
 }
 
+// Verify checks whether the device is INA219.
+// There is no ID register, so reserved bits, which always read as zero, are checked instead.
 func (s *INA219) Verify() bool {
 	if !s.I2C.Verify() {
 		return false
 	}
 
-	if devID, err := s.I2C.ReadReg(BMP280_DEVICE_ID_REGISTER); err == nil {
-		return devID == BMP280_DEVICE_ID
+	config, err := s.I2C.ReadRegU16BE(INA219_CONFIG_REGISTER)
+	if err != nil || config&INA219_CONFIG_RESERVED_BIT != 0 {
+		return false
+	}
+
+	calibration, err := s.I2C.ReadRegU16BE(INA219_CALIBRATION_REGISTER)
+	if err != nil || calibration&INA219_CALIBRATION_VOID_BIT != 0 {
+		return false
 	}
 
-	return false
+	return true
 }
@@ -9,7 +9,13 @@ import (
 var i2cSensorsLocatorMap = map[uint16][]sensor.Factory {
 	0x1D: { sensor.I2CFactory(NewAccelerometerLSM303, LSM303C_A_ADDRESS) },
 	0x1E: { sensor.I2CFactory(NewMagnetometerLSM303, LSM303C_M_ADDRESS) },
-	0x40: { sensor.I2CFactory(NewHDC1080, HDC1080_ADDRESS) },
+	0x40: {
+		sensor.I2CFactory(NewHDC1080, HDC1080_ADDRESS),
+		sensor.I2CFactory(NewINA219, INA219_GND_ADDRESS),
+	},
+	0x41: { sensor.I2CFactory(NewINA219, INA219_VS_ADDRESS) },
+	0x44: { sensor.I2CFactory(NewINA219, INA219_ADDRESS) },
+	0x45: { sensor.I2CFactory(NewINA219, INA219_ALT_ADDRESS) },
 	0x48: { sensor.I2CFactory(NewADCHall, ADC_HALL_ADDRESS) },
 	0x49: { sensor.I2CFactory(NewADCMicrophone, ADC_MICROPHONE_ADDRESS) },
 	0x4A: {
@@ -24,12 +30,29 @@ var i2cSensorsLocatorMap = map[uint16][]sensor.Factory {
 	0x57: { sensor.I2CFactory(NewMAX30102, MAX30102_ADDRESS) },
 	0x5A: { sensor.I2CFactory(NewCCS811, CCS811_ADDRESS) },
 	0x60: { sensor.I2CFactory(NewSI1145, SI1145_ADDRESS) },
-	0x76: { sensor.I2CFactory(NewBMXX80, BMP280_ADDRESS) },
+	0x61: { sensor.I2CFactory(NewSCD30, SCD30_ADDRESS) },
+	0x62: { sensor.I2CFactory(NewSCD4X, SCD4X_ADDRESS) },
+	0x76: {
+		sensor.I2CFactory(NewBME680, BMXX80_ADDRESS),
+		sensor.I2CFactory(NewBME280, BMXX80_ADDRESS),
+		sensor.I2CFactory(NewBMP280, BMXX80_ADDRESS),
+	},
+	0x77: {
+		sensor.I2CFactory(NewBME680, BMXX80_ALT_ADDRESS),
+		sensor.I2CFactory(NewBME280, BMXX80_ALT_ADDRESS),
+		sensor.I2CFactory(NewBMP280, BMXX80_ALT_ADDRESS),
+	},
 	0x88: { sensor.I2CFactory(NewI2CSensorMock, MOCK_ADDRESS) },
 }
 
 // LocateI2CSensor locates I2C-based sensor.Sensor and provides its sensor.Factory.
+// Device's own power meter isn't located, so that it won't be detected as the external sensor.
 func LocateI2CSensor(addr uint16, bus int) (sensor.Factory, bool) {
+	if viper.GetBool("power.meter.enabled") &&
+		addr == uint16(viper.GetUint("power.meter.address")) && bus == viper.GetInt("power.meter.bus") {
+		return nil, false
+	}
+
 	if factories, ok := i2cSensorsLocatorMap[addr]; ok {
 		for i, f := range factories {
 			if f.Build(bus).Verify() {
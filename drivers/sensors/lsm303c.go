@@ -1,16 +1,23 @@
 package sensors
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/bskari/go-lsm303"
+	"github.com/pkg/errors"
 	"github.com/timoth-y/chainmetric-core/models"
 
 	"github.com/timoth-y/chainmetric-core/models/metrics"
 
+	"github.com/timoth-y/chainmetric-iot/core/compass"
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
 	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
 	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/shared"
 )
 
 
@@ -30,6 +37,10 @@ type (
 	LSM303Magnetometer struct {
 		*periphery.I2C
 		dev  *lsm303.Magnetometer
+		bus  int
+		calibration compass.Calibration
+		// accelerometer is a built-in into the same LSM303C package one, used for heading tilt compensation.
+		accelerometer *LSM303Accelerometer
 	}
 )
 
@@ -42,6 +53,9 @@ func NewAccelerometerLSM303(addr uint16, bus int) sensor.Sensor {
 func NewMagnetometerLSM303(addr uint16, bus int) sensor.Sensor {
 	return &LSM303Magnetometer{
 		I2C: periphery.NewI2C(addr, bus, periphery.WithMutex(lsm303cMagnetometerMutex)),
+		bus: bus,
+		calibration: compass.Identity(),
+		accelerometer: NewAccelerometerLSM303(LSM303C_A_ADDRESS, bus).(*LSM303Accelerometer),
 	}
 }
 
@@ -115,11 +129,103 @@ func (s *LSM303Magnetometer) Init() (err error) {
 		return
 	}
 
+	if err := s.accelerometer.Init(); err != nil {
+		shared.Logger.Warning(errors.Wrapf(err, "%s heading won't be tilt compensated", s.ID()))
+
+		if s.accelerometer.Active() {
+			_ = s.accelerometer.Close()
+		}
+	}
+
 	return
 }
 
-// ReadAxes parses data returned as magnetic force vector
+// ReadAxes parses data returned as magnetic force vector, corrected with calibration.
 func (s *LSM303Magnetometer) ReadAxes() (model.Vector, error) {
+	v, err := s.ReadRawAxes(); if err != nil {
+		return model.Vector{}, err
+	}
+
+	s.Lock()
+	calibration := s.calibration
+	s.Unlock()
+
+	return calibration.Apply(v), nil
+}
+
+// ReadHeading determines compass heading in degrees, compensating the device tilt with accelerometer data.
+func (s *LSM303Magnetometer) ReadHeading() (float64, error) {
+	mag, err := s.ReadAxes(); if err != nil {
+		return 0, err
+	}
+
+	// Assuming the device is leveled when accelerometer isn't available:
+	acc := model.Vector{Z: 1}
+	if s.accelerometer.Active() {
+		if acc, err = s.accelerometer.ReadAxes(); err != nil {
+			return 0, err
+		}
+	}
+
+	return compass.Heading(mag, acc), nil
+}
+
+// Calibrate collects raw samples while the device is being rotated in all directions during given `duration`,
+// then fits hard-iron and soft-iron calibration, which is applied on read and returned encoded to be persisted.
+func (s *LSM303Magnetometer) Calibrate(ctx context.Context, duration time.Duration) ([]byte, error) {
+	var (
+		samples []model.Vector
+		ticker  = time.NewTicker(LSM303C_M_CALIBRATION_INTERVAL)
+		timeout = time.After(duration)
+	)
+
+	defer ticker.Stop()
+
+LOOP:
+	for {
+		select {
+		case <-ticker.C:
+			v, err := s.ReadRawAxes(); if err != nil {
+				return nil, errors.Wrap(err, "failed to read calibration sample")
+			}
+
+			samples = append(samples, v)
+		case <-timeout:
+			break LOOP
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	calibration, err := compass.Fit(samples)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fit calibration")
+	}
+
+	s.Lock()
+	s.calibration = calibration
+	s.Unlock()
+
+	return json.Marshal(calibration)
+}
+
+// RestoreCalibration applies hard-iron and soft-iron calibration encoded by Calibrate before.
+func (s *LSM303Magnetometer) RestoreCalibration(data []byte) error {
+	var calibration compass.Calibration
+
+	if err := json.Unmarshal(data, &calibration); err != nil {
+		return errors.Wrap(err, "failed to decode calibration")
+	}
+
+	s.Lock()
+	s.calibration = calibration
+	s.Unlock()
+
+	return nil
+}
+
+// ReadRawAxes parses data returned as magnetic force vector without calibration.
+func (s *LSM303Magnetometer) ReadRawAxes() (model.Vector, error) {
 	s.Lock()
 	defer s.Unlock()
 
@@ -150,12 +256,14 @@ func (s *LSM303Magnetometer) ID() string {
 func (s *LSM303Magnetometer) Harvest(ctx *sensor.Context) {
 	ctx.WriterFor(metrics.Magnetism).WriteWithError(toMagnitude(s.ReadAxes()))
 	ctx.WriterFor(metrics.Temperature).WriteWithError(s.ReadTemperature())
+	ctx.WriterFor(model.Heading).WriteWithError(s.ReadHeading())
 }
 
 func (s *LSM303Magnetometer) Metrics() []models.Metric {
 	return []models.Metric{
 		metrics.Magnetism,
 		metrics.Temperature,
+		model.Heading,
 	}
 }
 
@@ -170,3 +278,19 @@ func (s *LSM303Magnetometer) Verify() bool {
 
 	return false
 }
+
+func (s *LSM303Magnetometer) Close() error {
+	if s.accelerometer.Active() {
+		if err := s.accelerometer.Close(); err != nil {
+			shared.Logger.Error(errors.Wrapf(err, "failed to close %s built-in accelerometer", s.ID()))
+		}
+	}
+
+	return s.I2C.Close()
+}
+
+// CalibrationKey forms key for persisting calibration of this sensor instance.
+// It is based on the bus and address given on construction, so that it's the same before and after Init.
+func (s *LSM303Magnetometer) CalibrationKey() string {
+	return fmt.Sprintf("%s_%d_%#x", s.ID(), s.bus, s.Addr)
+}
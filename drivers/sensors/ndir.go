@@ -0,0 +1,144 @@
+package sensors
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/chainmetric-core/models/metrics"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+// ndir implements common functionality of the Sensirion NDIR CO2 sensors.
+type ndir struct {
+	sensirion
+	config    config.NDIRConfig
+	last      *ndirReadings
+	startedAt time.Time
+}
+
+// ndirReadings defines single measurement results of the NDIR CO2 sensor.
+type ndirReadings struct {
+	CO2         float64
+	Temperature float64
+	Humidity    float64
+}
+
+func newNDIR(i2c *periphery.I2C) *ndir {
+	cfg := config.NDIRConfig{}
+
+	if err := shared.UnmarshalFromConfig("sensors.ndir", &cfg); err != nil {
+		shared.Logger.Error(errors.Wrap(err, "failed to parse NDIR sensors config"))
+	}
+
+	return &ndir{
+		sensirion: sensirion{i2c},
+		config:    cfg,
+	}
+}
+
+func (n *ndir) Metrics() []models.Metric {
+	return []models.Metric{
+		metrics.AirCO2Concentration,
+		metrics.Temperature,
+		metrics.Humidity,
+	}
+}
+
+// Priority implements sensor.Prioritized, so that true CO2 concentration measured by NDIR sensor
+// takes precedence over one estimated from VOCs by other sensors, like CCS811.
+func (n *ndir) Priority(metric models.Metric) sensor.Priority {
+	if metric == metrics.AirCO2Concentration {
+		return sensor.PriorityHigh
+	}
+
+	return sensor.PriorityDefault
+}
+
+// measuring determines whether the sensor measurement was started by this driver instance.
+// Measurement started before device restart isn't trusted, since sensor configuration might have been changed since then.
+func (n *ndir) measuring() bool {
+	return !n.startedAt.IsZero()
+}
+
+// harvest awaits until new measurement is `ready` and `read`s it into the context.
+// In case measurement isn't ready before the context is done, the last one is used.
+func (n *ndir) harvest(ctx *sensor.Context, ready func() (bool, error), read func() (ndirReadings, error)) {
+	ticker := time.NewTicker(NDIR_DATA_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		ok, err := ready()
+		if err != nil {
+			ctx.Error(errors.Wrap(err, "failed to check data readiness"))
+			return
+		}
+
+		if ok {
+			readings, err := read()
+			if err != nil {
+				ctx.Error(errors.Wrap(err, "failed to read measurement"))
+				return
+			}
+
+			n.last = &readings
+			break
+		}
+
+		// Leaving some time margin to write last measurement before the reading timeout:
+		deadline, hasDeadline := ctx.Deadline()
+		if hasDeadline && time.Until(deadline) < 2*NDIR_DATA_POLL_INTERVAL {
+			break
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+		}
+
+		break
+	}
+
+	if n.last == nil {
+		ctx.Error(errors.New("measurement is not ready yet"))
+		return
+	}
+
+	ctx.WriterFor(metrics.AirCO2Concentration).Write(n.last.CO2)
+	ctx.WriterFor(metrics.Temperature).Write(n.last.Temperature)
+	ctx.WriterFor(metrics.Humidity).Write(n.last.Humidity)
+}
+
+// recalibrate performs forced recalibration to the `referencePPM` CO2 concentration,
+// once the sensor was measuring for at least `warmUp` duration since the measurement was started.
+func (n *ndir) recalibrate(referencePPM int, warmUp time.Duration, recalibrate func(ppm uint16) error) error {
+	if referencePPM <= 0 || referencePPM > math.MaxUint16 {
+		return errors.Errorf("reference CO2 concentration %d ppm is out of range", referencePPM)
+	}
+
+	if !n.measuring() || time.Since(n.startedAt) < warmUp {
+		return errors.Errorf("sensor must be measuring at least %s before recalibration", warmUp)
+	}
+
+	if err := recalibrate(uint16(referencePPM)); err != nil {
+		return errors.Wrap(err, "forced recalibration failed")
+	}
+
+	n.last = nil
+
+	return nil
+}
+
+func boolToWord(b bool) uint16 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,115 @@
+package sensors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+)
+
+var (
+	scd30Mutex = &sync.Mutex{}
+)
+
+// SCD30 defines Sensirion NDIR CO2, temperature, and humidity sensor device.
+type SCD30 struct {
+	*ndir
+}
+
+func NewSCD30(addr uint16, bus int) sensor.Sensor {
+	return &SCD30{
+		ndir: newNDIR(periphery.NewI2C(addr, bus, periphery.WithMutex(scd30Mutex))),
+	}
+}
+
+func (s *SCD30) ID() string {
+	return "SCD30"
+}
+
+func (s *SCD30) Init() error {
+	if err := s.I2C.Init(); err != nil {
+		return err
+	}
+
+	if s.measuring() {
+		return nil
+	} // Sensor is waking up from standby, while continuous measurement is still running.
+
+	if interval := s.config.MeasurementInterval; interval > 0 {
+		if err := s.sendCommand(SCD30_MEASUREMENT_INTERVAL, uint16(interval.Seconds())); err != nil {
+			return errors.Wrap(err, "failed to set measurement interval")
+		}
+	}
+
+	if err := s.sendCommand(SCD30_AUTO_SELF_CALIBRATION, boolToWord(s.config.AutoSelfCalibration)); err != nil {
+		return errors.Wrap(err, "failed to set automatic self-calibration")
+	}
+
+	if err := s.sendCommand(SCD30_START_CONTINUOUS, uint16(s.config.AmbientPressure)); err != nil {
+		return errors.Wrap(err, "failed to start continuous measurement")
+	}
+
+	s.startedAt = time.Now()
+
+	return nil
+}
+
+// IsDataReady checks whether the new measurement is available to be read.
+func (s *SCD30) IsDataReady() (bool, error) {
+	words, err := s.readWords(SCD30_DATA_READY, 1, SCD30_COMMAND_DELAY)
+	if err != nil {
+		return false, err
+	}
+
+	return words[0] == 1, nil
+}
+
+// Read retrieves CO2 concentration in ppm, temperature in °C, and relative humidity in %.
+func (s *SCD30) Read() (ndirReadings, error) {
+	words, err := s.readWords(SCD30_READ_MEASUREMENT, 6, SCD30_COMMAND_DELAY)
+	if err != nil {
+		return ndirReadings{}, err
+	}
+
+	return ndirReadings{
+		CO2:         round(sensirionFloat(words[0], words[1]), 2),
+		Temperature: round(sensirionFloat(words[2], words[3]), 2),
+		Humidity:    round(sensirionFloat(words[4], words[5]), 2),
+	}, nil
+}
+
+func (s *SCD30) Harvest(ctx *sensor.Context) {
+	s.harvest(ctx, s.IsDataReady, s.Read)
+}
+
+// RecalibrationWarmUp returns duration of continuous measurement required before forced recalibration.
+func (s *SCD30) RecalibrationWarmUp() time.Duration {
+	return SCD30_RECALIBRATION_WARM_UP
+}
+
+// Recalibrate performs forced recalibration, expecting the device to be placed in the reference environment.
+func (s *SCD30) Recalibrate(referencePPM int) error {
+	return s.recalibrate(referencePPM, SCD30_RECALIBRATION_WARM_UP, func(ppm uint16) error {
+		return s.sendCommand(SCD30_FORCED_RECALIBRATION, ppm)
+	})
+}
+
+func (s *SCD30) Verify() bool {
+	if !s.I2C.Verify() {
+		return false
+	}
+
+	// SCD30 has no device ID register, so valid CRC of the firmware version response is used instead:
+	_, err := s.readWords(SCD30_FIRMWARE_VERSION, 1, SCD30_COMMAND_DELAY)
+
+	return err == nil
+}
+
+// Close closes connection to the sensor, while continuous measurement keeps running,
+// so that the sensor won't need to warm up again once it is woken up from standby.
+func (s *SCD30) Close() error {
+	return s.I2C.Close()
+}
@@ -0,0 +1,153 @@
+package sensors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+)
+
+var (
+	scd4xMutex = &sync.Mutex{}
+)
+
+// SCD4X defines Sensirion SCD40/SCD41 photoacoustic NDIR CO2, temperature, and humidity sensor device.
+type SCD4X struct {
+	*ndir
+}
+
+func NewSCD4X(addr uint16, bus int) sensor.Sensor {
+	return &SCD4X{
+		ndir: newNDIR(periphery.NewI2C(addr, bus, periphery.WithMutex(scd4xMutex))),
+	}
+}
+
+func (s *SCD4X) ID() string {
+	return "SCD4X"
+}
+
+func (s *SCD4X) Init() error {
+	if err := s.I2C.Init(); err != nil {
+		return err
+	}
+
+	if s.measuring() {
+		return nil
+	} // Sensor is waking up from standby, while periodic measurement is still running.
+
+	// Sensor accepts configuration commands only in idle mode,
+	// which might not be the case if it wasn't properly stopped before:
+	if err := s.stopPeriodicMeasurement(); err != nil {
+		return err
+	}
+
+	if err := s.sendCommand(SCD4X_AUTO_SELF_CALIBRATION, boolToWord(s.config.AutoSelfCalibration)); err != nil {
+		return errors.Wrap(err, "failed to set automatic self-calibration")
+	}
+
+	// Pressure is set in hPa, which is the same as mbar used in configuration:
+	if s.config.AmbientPressure > 0 {
+		if err := s.sendCommand(SCD4X_AMBIENT_PRESSURE, uint16(s.config.AmbientPressure)); err != nil {
+			return errors.Wrap(err, "failed to set ambient pressure")
+		}
+	}
+
+	if err := s.sendCommand(SCD4X_START_PERIODIC); err != nil {
+		return errors.Wrap(err, "failed to start periodic measurement")
+	}
+
+	s.startedAt = time.Now()
+
+	return nil
+}
+
+// IsDataReady checks whether the new measurement is available to be read.
+func (s *SCD4X) IsDataReady() (bool, error) {
+	words, err := s.readWords(SCD4X_DATA_READY, 1, SCD4X_COMMAND_DELAY)
+	if err != nil {
+		return false, err
+	}
+
+	return words[0]&SCD4X_DATA_READY_MASK != 0, nil
+}
+
+// Read retrieves CO2 concentration in ppm, temperature in °C, and relative humidity in %.
+func (s *SCD4X) Read() (ndirReadings, error) {
+	words, err := s.readWords(SCD4X_READ_MEASUREMENT, 3, SCD4X_COMMAND_DELAY)
+	if err != nil {
+		return ndirReadings{}, err
+	}
+
+	return ndirReadings{
+		CO2:         float64(words[0]),
+		Temperature: round(-45+175*float64(words[1])/65535, 2),
+		Humidity:    round(100*float64(words[2])/65535, 2),
+	}, nil
+}
+
+func (s *SCD4X) Harvest(ctx *sensor.Context) {
+	s.harvest(ctx, s.IsDataReady, s.Read)
+}
+
+// RecalibrationWarmUp returns duration of periodic measurement required before forced recalibration.
+func (s *SCD4X) RecalibrationWarmUp() time.Duration {
+	return SCD4X_RECALIBRATION_WARM_UP
+}
+
+// Recalibrate performs forced recalibration, expecting the device to be placed in the reference environment.
+func (s *SCD4X) Recalibrate(referencePPM int) error {
+	return s.recalibrate(referencePPM, SCD4X_RECALIBRATION_WARM_UP, func(ppm uint16) error {
+		if err := s.stopPeriodicMeasurement(); err != nil {
+			return err
+		}
+
+		words, err := s.readWords(SCD4X_FORCED_RECALIBRATION, 1, SCD4X_RECALIBRATION_DELAY, ppm)
+
+		if err := s.sendCommand(SCD4X_START_PERIODIC); err != nil {
+			return errors.Wrap(err, "failed to restart periodic measurement")
+		}
+
+		s.startedAt = time.Now()
+
+		if err != nil {
+			return err
+		}
+
+		if words[0] == SCD4X_RECALIBRATION_FAILED {
+			return errors.New("sensor rejected recalibration, it must operate at least 3 minutes beforehand")
+		}
+
+		return nil
+	})
+}
+
+func (s *SCD4X) Verify() bool {
+	if !s.I2C.Verify() {
+		return false
+	}
+
+	// SCD4x has no device ID register, so valid CRC of the data readiness response is used instead,
+	// since unlike most of the commands it is accepted during periodic measurement:
+	_, err := s.readWords(SCD4X_DATA_READY, 1, SCD4X_COMMAND_DELAY)
+
+	return err == nil
+}
+
+// Close closes connection to the sensor, while periodic measurement keeps running,
+// so that the sensor won't need to warm up again once it is woken up from standby.
+func (s *SCD4X) Close() error {
+	return s.I2C.Close()
+}
+
+func (s *SCD4X) stopPeriodicMeasurement() error {
+	if err := s.sendCommand(SCD4X_STOP_PERIODIC); err != nil {
+		return errors.Wrap(err, "failed to stop periodic measurement")
+	}
+
+	time.Sleep(SCD4X_STOP_DELAY)
+
+	return nil
+}
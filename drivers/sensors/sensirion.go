@@ -0,0 +1,94 @@
+package sensors
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/drivers/periphery"
+)
+
+// sensirion implements I2C command protocol shared by Sensirion sensors (SCD30, SCD4x),
+// where each command is a 16-bit word, and each data word is followed by CRC-8 checksum.
+type sensirion struct {
+	*periphery.I2C
+}
+
+// sendCommand writes `cmd` command with optional `args` words to the sensor.
+func (s sensirion) sendCommand(cmd uint16, args ...uint16) error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.Tx(encodeSensirionFrame(cmd, args...), nil)
+}
+
+// readWords writes `cmd` command with optional `args` words, waits for `delay` and reads `n` data words from the sensor.
+func (s sensirion) readWords(cmd uint16, n int, delay time.Duration, args ...uint16) ([]uint16, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.Tx(encodeSensirionFrame(cmd, args...), nil); err != nil {
+		return nil, err
+	}
+
+	time.Sleep(delay)
+
+	buf := make([]byte, n*3)
+	if err := s.Tx(nil, buf); err != nil {
+		return nil, err
+	}
+
+	return decodeSensirionWords(buf)
+}
+
+func encodeSensirionFrame(cmd uint16, args ...uint16) []byte {
+	frame := make([]byte, 2, 2+len(args)*3)
+	binary.BigEndian.PutUint16(frame, cmd)
+
+	for _, arg := range args {
+		word := []byte{byte(arg >> 8), byte(arg)}
+		frame = append(frame, word[0], word[1], sensirionCRC(word))
+	}
+
+	return frame
+}
+
+func decodeSensirionWords(buf []byte) ([]uint16, error) {
+	words := make([]uint16, len(buf)/3)
+
+	for i := range words {
+		chunk := buf[i*3 : i*3+3]
+		if sensirionCRC(chunk[:2]) != chunk[2] {
+			return nil, errors.Errorf("CRC mismatch in data word %d", i)
+		}
+
+		words[i] = binary.BigEndian.Uint16(chunk[:2])
+	}
+
+	return words, nil
+}
+
+// sensirionCRC calculates CRC-8 checksum with 0x31 polynomial and 0xFF initialization.
+func sensirionCRC(data []byte) byte {
+	crc := byte(SENSIRION_CRC_INIT)
+
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ SENSIRION_CRC_POLYNOMIAL
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// sensirionFloat decodes IEEE 754 float transmitted as two big endian words.
+func sensirionFloat(msw, lsw uint16) float64 {
+	return float64(math.Float32frombits(uint32(msw)<<16 | uint32(lsw)))
+}
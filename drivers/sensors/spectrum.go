@@ -0,0 +1,158 @@
+package sensors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/timoth-y/chainmetric-core/models"
+
+	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/core/spectrum"
+	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/shared"
+)
+
+var (
+	spectrumConfigs      = make(map[string]config.SpectrumConfig)
+	spectrumConfigsMutex = &sync.Mutex{}
+)
+
+// spectrumAnalyzer defines sensor-specific spectrum analysis of the sampled signal.
+type spectrumAnalyzer struct {
+	config.SpectrumConfig
+}
+
+// newSpectrumAnalyzer constructs spectrumAnalyzer for the sensor with given `id`,
+// based on `sensors.spectrum.<id>` configuration block.
+func newSpectrumAnalyzer(id string) spectrumAnalyzer {
+	spectrumConfigsMutex.Lock()
+	defer spectrumConfigsMutex.Unlock()
+
+	if cfg, ok := spectrumConfigs[id]; ok {
+		return spectrumAnalyzer{cfg}
+	}
+
+	var (
+		key = fmt.Sprintf("sensors.spectrum.%s", strings.ToLower(id))
+		cfg = config.SpectrumConfig{}
+	)
+
+	if err := shared.UnmarshalFromConfig(key, &cfg); err != nil {
+		shared.Logger.Error(errors.Wrapf(err, "failed to parse spectrum config for '%s' sensor", id))
+	}
+
+	if cfg.Samples <= 0 {
+		cfg.Samples = SPECTRUM_DEFAULT_SAMPLES
+	}
+
+	if len(cfg.Bands) == 0 {
+		cfg.Bands = defaultVibrationBands()
+	}
+
+	spectrumConfigs[id] = cfg
+
+	return spectrumAnalyzer{cfg}
+}
+
+// vibrationMetrics returns vibration metrics available with analyzer's configuration.
+func (a spectrumAnalyzer) vibrationMetrics() []models.Metric {
+	if !a.Enabled {
+		return nil
+	}
+
+	metrics := []models.Metric{
+		model.VibrationFrequency,
+		model.VibrationPeak,
+	}
+
+	for _, band := range a.Bands {
+		metrics = append(metrics, model.VibrationBandRMS(band.Name))
+	}
+
+	return metrics
+}
+
+// requestedIn determines whether any of the spectrum-based `metrics` is requested in the reading context `ctx`,
+// so that signal sequence is sampled only when its analysis is actually needed.
+func (a spectrumAnalyzer) requestedIn(ctx *sensor.Context, metrics ...models.Metric) bool {
+	if !a.Enabled {
+		return false
+	}
+
+	for _, metric := range metrics {
+		if _, ok := ctx.Pipe[metric]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// harvestVibration analyses `samples` taken with given `rate` and writes vibration metrics into `ctx`.
+func (a spectrumAnalyzer) harvestVibration(ctx *sensor.Context, samples []float64, rate float64) {
+	var (
+		sp = a.analyze(samples, rate)
+	)
+
+	ctx.WriterFor(model.VibrationFrequency).Write(sp.DominantFrequency())
+	ctx.WriterFor(model.VibrationPeak).Write(spectrum.Peak(samples))
+
+	for _, band := range a.Bands {
+		to := band.To
+		if to <= 0 {
+			to = math.Inf(1)
+		}
+
+		ctx.WriterFor(model.VibrationBandRMS(band.Name)).Write(sp.BandRMS(band.From, to))
+	}
+}
+
+func (a spectrumAnalyzer) analyze(samples []float64, rate float64) spectrum.Spectrum {
+	return spectrum.Analyze(samples, rate, spectrum.WindowByName(a.Window))
+}
+
+// sampleRate determines actual sampling rate in Hz for `n` samples taken over `elapsed` time.
+func sampleRate(n int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(n) / elapsed.Seconds()
+}
+
+// sampleSequence takes `n` samples with `read` function with given `interval`
+// and returns them along with actual sampling rate.
+func sampleSequence(n int, interval time.Duration, read func() (float64, error)) ([]float64, float64, error) {
+	var (
+		samples = make([]float64, 0, n)
+		start   = time.Now()
+	)
+
+	for i := 0; i < n; i++ {
+		v, err := read()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		samples = append(samples, v)
+
+		if interval > 0 {
+			time.Sleep(interval)
+		}
+	}
+
+	return samples, sampleRate(len(samples), time.Since(start)), nil
+}
+
+func defaultVibrationBands() []config.BandConfig {
+	return []config.BandConfig{
+		{Name: "low", From: 0, To: 10},
+		{Name: "mid", From: 10, To: 100},
+		{Name: "high", From: 100},
+	}
+}
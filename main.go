@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/exec"
 	"os/signal"
 
 	"github.com/spf13/viper"
-	"github.com/timoth-y/chainmetric-iot/controllers/device/modules"
+	_ "github.com/timoth-y/chainmetric-iot/controllers/device/modules" // registers built-in modules
 	"github.com/timoth-y/chainmetric-iot/controllers/gui"
 	core "github.com/timoth-y/chainmetric-iot/core/dev"
 	dsp "github.com/timoth-y/chainmetric-iot/drivers/display"
@@ -14,8 +16,10 @@ import (
 	dev "github.com/timoth-y/chainmetric-iot/controllers/device"
 	"github.com/timoth-y/chainmetric-iot/drivers/sensors"
 	"github.com/timoth-y/chainmetric-iot/model/config"
+	"github.com/timoth-y/chainmetric-iot/model/events"
 	"github.com/timoth-y/chainmetric-iot/network/blockchain"
 	"github.com/timoth-y/chainmetric-iot/shared"
+	"github.com/timoth-y/go-eventdriver"
 )
 
 var (
@@ -26,6 +30,8 @@ var (
 
 	done = make(chan struct{}, 1)
 	quit = make(chan os.Signal, 1)
+
+	shutdownRequest events.DeviceShutdownRequestedPayload
 )
 
 func init() {
@@ -33,21 +39,29 @@ func init() {
 
 	shared.MustUnmarshalFromConfig("display", &dcf)
 
-	device = dev.New(
-		modules.WithLifecycleManager(),
-		modules.WithEngineOperator(),
-		modules.WithCacheManager(),
-		modules.WithEventsObserver(),
-		modules.WithHotswapDetector(),
-		modules.WithRemoteController(),
-		modules.WithLocationManager(),
-		modules.WithPowerManager(),
-		modules.WithFailoverHandler(),
-		modules.WithGUIRenderer(),
-	)
-
-	display = dsp.NewEInk(dcf)
+	device = dev.New(dev.ModulesFromConfig()...)
+
+	var err error
+	if display, err = dsp.New(dcf); err != nil {
+		shared.Logger.Fatal(err)
+	}
+
 	gui.Init(display)
+
+	eventdriver.SubscribeHandler(events.DeviceShutdownRequested, func(_ context.Context, v interface{}) error {
+		if payload, ok := v.(events.DeviceShutdownRequestedPayload); ok {
+			shutdownRequest = payload
+
+			select {
+			case quit <- os.Interrupt:
+			default:
+			} // Shutdown is already in progress.
+
+			return nil
+		}
+
+		return eventdriver.ErrIncorrectPayload
+	})
 }
 
 func main() {
@@ -80,16 +94,29 @@ func shutdown() {
 	<-quit
 	shared.Logger.Info("Shutting down...")
 
+	// Device goes first, so that modules won't render over the shutdown frame:
+	shared.Execute(localnet.Close, "error during closing local network")
+	shared.Execute(device.Close, "error during device shutdown")
+
 	if dcf.Enabled {
-		shared.Execute(display.ClearAndRefresh, "error during clearing display")
+		// Shutdown reason is kept on display, e-ink panel will show it even after power is lost:
+		if len(shutdownRequest.Reason) != 0 {
+			gui.RenderWarningMsg(shutdownRequest.Reason)
+			gui.Flush()
+		} else {
+			shared.Execute(display.ClearAndRefresh, "error during clearing display")
+		}
+
 		shared.Execute(display.Close, "error during closing connection to display")
 	}
 
-	shared.Execute(localnet.Close, "error during closing local network")
-	shared.Execute(device.Close, "error during device shutdown")
-
 	blockchain.Close()
 	shared.CloseCore()
 
+	if len(shutdownRequest.Command) != 0 {
+		shared.Logger.Infof("Executing system shutdown command: %s", shutdownRequest.Command)
+		shared.Execute(exec.Command("sh", "-c", shutdownRequest.Command).Run, "error during executing shutdown command")
+	}
+
 	close(done)
 }
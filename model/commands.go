@@ -0,0 +1,88 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/timoth-y/chainmetric-core/models"
+	"github.com/timoth-y/chainmetric-core/models/requests"
+)
+
+// Extended device commands, which are supported in addition to ones defined in models.DeviceCommand.
+const (
+	// DeviceCalibrateCmd defines command for performing calibration routine of the sensors requiring one.
+	DeviceCalibrateCmd models.DeviceCommand = "calibrate"
+	// DeviceCalibrateCO2Cmd defines command for performing forced recalibration of the CO2 sensors in the reference environment.
+	DeviceCalibrateCO2Cmd models.DeviceCommand = "calibrate_co2"
+	// DeviceRebootCmd defines command for rebooting the device.
+	DeviceRebootCmd models.DeviceCommand = "reboot"
+	// DeviceRescanSensorsCmd defines command for scanning device's periphery for attached and detached sensors right away.
+	DeviceRescanSensorsCmd models.DeviceCommand = "rescan_sensors"
+	// DeviceResetIdentityCmd defines command for unbinding device from network and removing its stored identity.
+	DeviceResetIdentityCmd models.DeviceCommand = "reset_identity"
+	// DeviceFlushCacheCmd defines command for dropping readings stored in failover cache.
+	DeviceFlushCacheCmd models.DeviceCommand = "flush_failover_cache"
+	// DeviceRepostCacheCmd defines command for posting readings stored in failover cache right away.
+	DeviceRepostCacheCmd models.DeviceCommand = "repost_failover_cache"
+	// DeviceReadNowCmd defines command for taking and posting sensor readings for the asset right away.
+	DeviceReadNowCmd models.DeviceCommand = "read_now"
+	// DeviceSelfTestCmd defines command for running self-tests of the registered sensors.
+	DeviceSelfTestCmd models.DeviceCommand = "self_test"
+	// DeviceDiagnosticsCmd defines command for collecting device diagnostics data.
+	DeviceDiagnosticsCmd models.DeviceCommand = "diagnostics"
+	// DeviceUpdateConfigCmd defines command for applying versioned configuration patch.
+	DeviceUpdateConfigCmd models.DeviceCommand = "update_config"
+)
+
+// DeviceCommandResults extends requests.DeviceCommandResultsSubmitRequest with execution progress
+// and structured output of the command.
+type DeviceCommandResults struct {
+	requests.DeviceCommandResultsSubmitRequest
+	Progress int         `json:"progress,omitempty"`
+	Stage    string      `json:"stage,omitempty"`
+	Output   interface{} `json:"output,omitempty"`
+}
+
+// Encode serializes the DeviceCommandResults model.
+func (r DeviceCommandResults) Encode() []byte {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// SensorSelfTest defines results of the sensor self-test.
+type SensorSelfTest struct {
+	SensorID string          `json:"sensor_id"`
+	Passed   bool            `json:"passed"`
+	Metrics  []models.Metric `json:"metrics,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// DeviceDiagnostics defines diagnostics data of the device collected by remote command.
+type DeviceDiagnostics struct {
+	Timestamp      time.Time            `json:"timestamp"`
+	State          models.DeviceState   `json:"state"`
+	Paused         bool                 `json:"paused"`
+	ConfigVersion  int                  `json:"config_version"`
+	ConfigPending  bool                 `json:"config_pending,omitempty"`
+	Specs          DeviceSpecs          `json:"specs"`
+	Battery        models.DeviceBattery `json:"battery"`
+	Sensors        []string             `json:"sensors"`
+	Modules        []ModuleHealth       `json:"modules"`
+	BlockedModules map[string][]string  `json:"blocked_modules,omitempty"`
+	CachedAssets   int                  `json:"cached_assets"`
+	CachedRequests int                  `json:"cached_requests"`
+	CachedReadings int                  `json:"cached_readings"`
+	Goroutines     int                  `json:"goroutines"`
+	MemoryAlloc    uint64               `json:"memory_alloc"`
+}
+
+// ConfigPatchResults defines results of the configuration patch applied by remote command.
+type ConfigPatchResults struct {
+	Version int      `json:"version"`
+	Applied []string `json:"applied,omitempty"`
+	Restart []string `json:"restart,omitempty"`
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+)
+
+// BMXX80Config defines configuration of the BMP280, BME280, and BME680 environmental sensors.
+type BMXX80Config struct {
+	// TemperatureOversampling is a number of temperature samples averaged per measurement (1, 2, 4, 8, 16).
+	TemperatureOversampling int `yaml:"temperature_oversampling" mapstructure:"temperature_oversampling"`
+	// PressureOversampling is a number of pressure samples averaged per measurement (0, 1, 2, 4, 8, 16).
+	PressureOversampling int `yaml:"pressure_oversampling" mapstructure:"pressure_oversampling"`
+	// HumidityOversampling is a number of humidity samples averaged per measurement (0, 1, 2, 4, 8, 16).
+	HumidityOversampling int `yaml:"humidity_oversampling" mapstructure:"humidity_oversampling"`
+	// Filter is an IIR filter coefficient (0, 2, 4, 8, 16, and also 32, 64, 128 for BME680).
+	Filter int `yaml:"filter" mapstructure:"filter"`
+	// HeaterTemperature is a BME680 gas sensor hot plate target temperature in °C.
+	HeaterTemperature int `yaml:"heater_temperature" mapstructure:"heater_temperature"`
+	// HeaterDuration is a BME680 gas sensor hot plate heating duration.
+	HeaterDuration time.Duration `yaml:"heater_duration" mapstructure:"heater_duration"`
+}
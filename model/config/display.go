@@ -1,15 +1,33 @@
 package config
 
+// DisplayConfig defines configuration of the display device.
 type DisplayConfig struct {
-	Enabled   bool   `yaml:"enabled" mapstructure:"enabled"`
-	Width     int    `yaml:"width" mapstructure:"width"`
-	Height    int    `yaml:"height" mapstructure:"height"`
-	Rotation  uint8  `yaml:"rotation" mapstructure:"rotation"`
-	FrameRate uint8  `yaml:"frame_rate" mapstructure:"frame_rate"`
-
-	Bus      string `yaml:"bus" mapstructure:"bus"`
-	DCPin    int    `yaml:"dc_pin" mapstructure:"dc_pin"`
-	CSPin    int    `yaml:"cs_pin" mapstructure:"cs_pin"`
-	ResetPin int    `yaml:"reset_pin" mapstructure:"reset_pin"`
-	BusyPin  int    `yaml:"busy_pin" mapstructure:"busy_pin"`
+	Enabled    bool   `yaml:"enabled" mapstructure:"enabled"`
+	Driver     string `yaml:"driver" mapstructure:"driver"`
+	Width      int    `yaml:"width" mapstructure:"width"`
+	Height     int    `yaml:"height" mapstructure:"height"`
+	Rotation   uint16 `yaml:"rotation" mapstructure:"rotation"`
+	FrameRate  uint8  `yaml:"frame_rate" mapstructure:"frame_rate"`
+	Brightness uint8  `yaml:"brightness" mapstructure:"brightness"`
+
+	FullRefreshInterval int `yaml:"full_refresh_interval" mapstructure:"full_refresh_interval"`
+
+	Bus          string `yaml:"bus" mapstructure:"bus"`
+	DCPin        int    `yaml:"dc_pin" mapstructure:"dc_pin"`
+	CSPin        int    `yaml:"cs_pin" mapstructure:"cs_pin"`
+	ResetPin     int    `yaml:"reset_pin" mapstructure:"reset_pin"`
+	BusyPin      int    `yaml:"busy_pin" mapstructure:"busy_pin"`
+	BacklightPin int    `yaml:"backlight_pin" mapstructure:"backlight_pin"`
+
+	I2CBus  int    `yaml:"i2c_bus" mapstructure:"i2c_bus"`
+	Address uint16 `yaml:"address" mapstructure:"address"`
+
+	Virtual VirtualDisplayConfig `yaml:"virtual" mapstructure:"virtual"`
+}
+
+// VirtualDisplayConfig defines configuration of the headless virtual display.
+type VirtualDisplayConfig struct {
+	OutputDir     string `yaml:"output_dir" mapstructure:"output_dir"`
+	HistorySize   int    `yaml:"history_size" mapstructure:"history_size"`
+	ListenAddress string `yaml:"listen_address" mapstructure:"listen_address"`
 }
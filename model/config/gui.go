@@ -0,0 +1,24 @@
+package config
+
+import (
+	"time"
+)
+
+// GUIConfig defines configuration of the device GUI navigation and appearance.
+type GUIConfig struct {
+	IdleTimeout        time.Duration `yaml:"idle_timeout" mapstructure:"idle_timeout"`
+	AssetCycleInterval time.Duration `yaml:"asset_cycle_interval" mapstructure:"asset_cycle_interval"`
+	AssetsDir          string        `yaml:"assets_dir" mapstructure:"assets_dir"`
+	Buttons            ButtonsConfig `yaml:"buttons" mapstructure:"buttons"`
+}
+
+// ButtonsConfig defines configuration of the physical buttons used for GUI navigation.
+// Zero pin number means that the button isn't connected.
+type ButtonsConfig struct {
+	Enabled   bool          `yaml:"enabled" mapstructure:"enabled"`
+	NextPin   int           `yaml:"next_pin" mapstructure:"next_pin"`
+	PrevPin   int           `yaml:"prev_pin" mapstructure:"prev_pin"`
+	SelectPin int           `yaml:"select_pin" mapstructure:"select_pin"`
+	Debounce  time.Duration `yaml:"debounce" mapstructure:"debounce"`
+	LongPress time.Duration `yaml:"long_press" mapstructure:"long_press"`
+}
@@ -0,0 +1,6 @@
+package config
+
+// ModuleConfig defines configuration of the device logical module, which is specified by its lowercased ID.
+type ModuleConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"time"
+)
+
+// MotionConfig defines configuration of the accelerometer-based motion analysis.
+type MotionConfig struct {
+	SampleInterval      time.Duration `yaml:"sample_interval" mapstructure:"sample_interval"`
+	BaselineSamples     int           `yaml:"baseline_samples" mapstructure:"baseline_samples"`
+	TiltThreshold       float64       `yaml:"tilt_threshold" mapstructure:"tilt_threshold"`
+	ShockThreshold      float64       `yaml:"shock_threshold" mapstructure:"shock_threshold"`
+	ShockMinDuration    time.Duration `yaml:"shock_min_duration" mapstructure:"shock_min_duration"`
+	FreeFallThreshold   float64       `yaml:"free_fall_threshold" mapstructure:"free_fall_threshold"`
+	FreeFallMinDuration time.Duration `yaml:"free_fall_min_duration" mapstructure:"free_fall_min_duration"`
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"time"
+)
+
+// NDIRConfig defines configuration of the NDIR CO2 sensors (SCD30, SCD4x).
+type NDIRConfig struct {
+	// AutoSelfCalibration enables sensor automatic self-calibration,
+	// which assumes device is exposed to fresh air regularly.
+	AutoSelfCalibration bool `yaml:"auto_self_calibration" mapstructure:"auto_self_calibration"`
+	// MeasurementInterval is an interval of continuous measurements (SCD30 only, SCD4x measures every 5s).
+	MeasurementInterval time.Duration `yaml:"measurement_interval" mapstructure:"measurement_interval"`
+	// AmbientPressure is an ambient pressure in mbar used for CO2 compensation, 0 disables compensation.
+	AmbientPressure int `yaml:"ambient_pressure" mapstructure:"ambient_pressure"`
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"time"
+)
+
+// PowerConfig defines configuration of the device power management.
+type PowerConfig struct {
+	AlertThreshold   int           `yaml:"alert_threshold" mapstructure:"alert_threshold"`
+	AlertPin         int           `yaml:"alert_pin" mapstructure:"alert_pin"`
+	EstimationWindow time.Duration `yaml:"estimation_window" mapstructure:"estimation_window"`
+
+	LowBatteryLevel int                 `yaml:"low_battery_level" mapstructure:"low_battery_level"`
+	CriticalLevel   int                 `yaml:"critical_level" mapstructure:"critical_level"`
+	Profiles        PowerProfilesConfig `yaml:"profiles" mapstructure:"profiles"`
+
+	Shutdown ShutdownConfig `yaml:"shutdown" mapstructure:"shutdown"`
+
+	Meter PowerMeterConfig `yaml:"meter" mapstructure:"meter"`
+}
+
+// PowerMeterConfig defines configuration of the INA219 power monitor measuring device's own consumption.
+type PowerMeterConfig struct {
+	Enabled        bool          `yaml:"enabled" mapstructure:"enabled"`
+	Address        uint16        `yaml:"address" mapstructure:"address"`
+	Bus            int           `yaml:"bus" mapstructure:"bus"`
+	SampleInterval time.Duration `yaml:"sample_interval" mapstructure:"sample_interval"`
+}
+
+// ShutdownConfig defines configuration of the graceful shutdown performed when battery is depleted.
+// Zero Voltage disables voltage-based depletion detection, and empty Command means system won't be powered off.
+type ShutdownConfig struct {
+	Level         int     `yaml:"level" mapstructure:"level"`
+	Voltage       float64 `yaml:"voltage" mapstructure:"voltage"`
+	Confirmations int     `yaml:"confirmations" mapstructure:"confirmations"`
+	Command       string  `yaml:"command" mapstructure:"command"`
+}
+
+// PowerProfilesConfig defines power profiles, which device switches between depending on its power source and battery level.
+type PowerProfilesConfig struct {
+	Mains      PowerProfileConfig `yaml:"mains" mapstructure:"mains"`
+	Battery    PowerProfileConfig `yaml:"battery" mapstructure:"battery"`
+	LowBattery PowerProfileConfig `yaml:"low_battery" mapstructure:"low_battery"`
+	Critical   PowerProfileConfig `yaml:"critical" mapstructure:"critical"`
+}
+
+// PowerProfileConfig defines device behaviour under the power profile.
+// Zero durations fall back to the corresponding base configuration options.
+type PowerProfileConfig struct {
+	HotswapInterval      time.Duration `yaml:"hotswap_interval" mapstructure:"hotswap_interval"`
+	GUIUpdateInterval    time.Duration `yaml:"gui_update_interval" mapstructure:"gui_update_interval"`
+	SensorStandbyTimeout time.Duration `yaml:"sensor_standby_timeout" mapstructure:"sensor_standby_timeout"`
+	Bluetooth            bool          `yaml:"bluetooth" mapstructure:"bluetooth"`
+	SamplingFactor       float64       `yaml:"sampling_factor" mapstructure:"sampling_factor"`
+}
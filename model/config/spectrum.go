@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+)
+
+// SpectrumConfig defines configuration of the sensor signal spectrum analysis.
+type SpectrumConfig struct {
+	Enabled        bool          `yaml:"enabled" mapstructure:"enabled"`
+	Samples        int           `yaml:"samples" mapstructure:"samples"`
+	SampleInterval time.Duration `yaml:"sample_interval" mapstructure:"sample_interval"`
+	Window         string        `yaml:"window" mapstructure:"window"`
+	Bands          []BandConfig  `yaml:"bands" mapstructure:"bands"`
+}
+
+// BandConfig defines frequency band for which signal energy should be reported.
+type BandConfig struct {
+	Name string  `yaml:"name" mapstructure:"name"`
+	From float64 `yaml:"from" mapstructure:"from"`
+	To   float64 `yaml:"to" mapstructure:"to"`
+}
@@ -0,0 +1,14 @@
+package config
+
+import (
+	"time"
+)
+
+// SupervisorConfig defines configuration of the device modules supervision.
+type SupervisorConfig struct {
+	RestartPolicy string            `yaml:"restart_policy" mapstructure:"restart_policy"`
+	Backoff       time.Duration     `yaml:"backoff" mapstructure:"backoff"`
+	MaxBackoff    time.Duration     `yaml:"max_backoff" mapstructure:"max_backoff"`
+	MaxRestarts   int               `yaml:"max_restarts" mapstructure:"max_restarts"`
+	Modules       map[string]string `yaml:"modules" mapstructure:"modules"`
+}
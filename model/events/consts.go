@@ -31,6 +31,33 @@ const (
 	// BluetoothPairingStarted identifies event for Bluetooth pairing process started.
 	BluetoothPairingStarted = "bluetooth.pairing.started"
 
+	// MotionDetected identifies event for tilt, shock, or free-fall detected by accelerometer.
+	MotionDetected = "motion.detected"
+
+	// SensorsCalibrationStarted identifies event for sensors calibration routine started.
+	SensorsCalibrationStarted = "sensors.calibration.started"
+
 	// LocationUpdateReceived identifies event for device location being updated.
 	LocationUpdateReceived = "location.update.received"
+
+	// BatteryStatusUpdated identifies event for battery status being read from fuel gauge.
+	BatteryStatusUpdated = "battery.status.updated"
+
+	// PowerProfileChanged identifies event for device switching to another power profile.
+	PowerProfileChanged = "power.profile.changed"
+
+	// DeviceShutdownRequested identifies event for graceful device shutdown being requested, e.g. due to battery depletion.
+	DeviceShutdownRequested = "device.shutdown.requested"
+
+	// ModuleHealthChanged identifies event for changes in health state of the device module.
+	ModuleHealthChanged = "module.health.changed"
+
+	// DevicePaused identifies event for device operation being paused, e.g. by remote command.
+	DevicePaused = "device.paused"
+
+	// DeviceResumed identifies event for paused device operation being resumed.
+	DeviceResumed = "device.resumed"
+
+	// ReadingsRequested identifies event for sensor readings for the asset being requested right away, e.g. by remote command.
+	ReadingsRequested = "readings.requested"
 )
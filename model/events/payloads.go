@@ -3,7 +3,9 @@ package events
 import (
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-iot/core/dev/sensor"
+	"github.com/timoth-y/chainmetric-iot/core/motion"
 	"github.com/timoth-y/chainmetric-iot/model"
+	"github.com/timoth-y/chainmetric-iot/model/config"
 )
 
 // DeviceLocationChangedPayload defines payload for DeviceLocationChanged event.
@@ -34,3 +36,46 @@ type SensorsRegisterChangedPayload struct {
 	Added   []sensor.Sensor
 	Removed []string
 }
+
+// MotionDetectedPayload defines payload for MotionDetected event.
+type MotionDetectedPayload struct {
+	motion.Event
+}
+
+// RequestHandledPayload defines payload for RequestHandled event.
+type RequestHandledPayload struct {
+	AssetID  string
+	Readings map[models.Metric]float64
+}
+
+// BatteryStatusUpdatedPayload defines payload for BatteryStatusUpdated event.
+type BatteryStatusUpdatedPayload struct {
+	model.BatteryStatus
+}
+
+// PowerProfileChangedPayload defines payload for PowerProfileChanged event.
+type PowerProfileChangedPayload struct {
+	Profile  model.PowerProfile
+	Settings config.PowerProfileConfig
+}
+
+// DeviceShutdownRequestedPayload defines payload for DeviceShutdownRequested event.
+type DeviceShutdownRequestedPayload struct {
+	// Reason is displayed instead of clearing the display, so that it stays visible on e-ink panel once power is lost.
+	Reason string
+	// Command is a system command executed at the end of shutdown sequence, e.g. to power off the board.
+	Command string
+}
+
+// ModuleHealthChangedPayload defines payload for ModuleHealthChanged event.
+type ModuleHealthChangedPayload struct {
+	model.ModuleHealth
+}
+
+// ReadingsRequestedPayload defines payload for ReadingsRequested event.
+type ReadingsRequestedPayload struct {
+	AssetID string
+	Metrics []models.Metric
+	// Results is a buffered channel, which receives taken readings once they are posted, or is closed if those can't be taken.
+	Results chan<- map[models.Metric]float64
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"github.com/timoth-y/chainmetric-core/models"
+)
+
+// Extended metrics, which are derived on the device side from the raw signal analysis.
+const (
+	// NoiseLevelA defines A-weighted sound level metric in dBA.
+	NoiseLevelA models.Metric = "nse_dba"
+	// VibrationFrequency defines dominant vibration frequency metric in Hz.
+	VibrationFrequency models.Metric = "vbr_hz"
+	// VibrationPeak defines peak vibration amplitude metric.
+	VibrationPeak models.Metric = "vbr_peak"
+
+	// GasResistance defines metal-oxide gas sensor resistance metric in Ohm.
+	GasResistance models.Metric = "gas"
+	// AirQualityIndex defines estimated indoor air quality index metric (0 - excellent, 500 - extremely polluted).
+	AirQualityIndex models.Metric = "iaq"
+
+	// Heading defines tilt-compensated compass heading metric in degrees clockwise from magnetic north.
+	Heading models.Metric = "hdg"
+
+	// MotionTilt defines metric for package tilt angle relative to its baseline orientation in degrees.
+	MotionTilt models.Metric = "mtn_tilt"
+	// MotionShock defines metric for peak acceleration of the shock event in G.
+	MotionShock models.Metric = "mtn_shock"
+	// MotionFreeFall defines metric for free-fall duration in seconds.
+	MotionFreeFall models.Metric = "mtn_fall"
+	// MotionAxisX defines metric for X axis acceleration at the moment of motion event in G.
+	MotionAxisX models.Metric = "mtn_x"
+	// MotionAxisY defines metric for Y axis acceleration at the moment of motion event in G.
+	MotionAxisY models.Metric = "mtn_y"
+	// MotionAxisZ defines metric for Z axis acceleration at the moment of motion event in G.
+	MotionAxisZ models.Metric = "mtn_z"
+
+	// RailVoltage defines metric for monitored power rail voltage in V.
+	RailVoltage models.Metric = "pwr_v"
+	// RailCurrent defines metric for monitored power rail current in mA.
+	RailCurrent models.Metric = "pwr_ma"
+	// RailPower defines metric for monitored power rail consumed power in mW.
+	RailPower models.Metric = "pwr_mw"
+)
+
+// VibrationBandRMS returns metric for vibration root mean square in the frequency band with given `name`.
+func VibrationBandRMS(name string) models.Metric {
+	return models.Metric("vbr_rms_" + name)
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+)
+
+// RestartPolicy defines whether device module must be restarted once its operational routine ends.
+type RestartPolicy string
+
+const (
+	// RestartNever defines policy, by which module is never restarted.
+	RestartNever RestartPolicy = "never"
+	// RestartOnFailure defines policy, by which module is restarted only when its routine failed or panicked.
+	RestartOnFailure RestartPolicy = "on-failure"
+	// RestartAlways defines policy, by which module is restarted whenever its routine ends.
+	RestartAlways RestartPolicy = "always"
+)
+
+// Valid determines whether the RestartPolicy is one of the known ones.
+func (p RestartPolicy) Valid() bool {
+	switch p {
+	case RestartNever, RestartOnFailure, RestartAlways:
+		return true
+	default:
+		return false
+	}
+}
+
+// ModuleStatus defines operational status of the device module.
+type ModuleStatus string
+
+const (
+	// ModuleDisabled defines status of the module, which setup failed.
+	ModuleDisabled ModuleStatus = "disabled"
+	// ModulePending defines status of the module awaiting for its dependencies or readiness conditions.
+	ModulePending ModuleStatus = "pending"
+	// ModuleRunning defines status of the module, which routine is running.
+	ModuleRunning ModuleStatus = "running"
+	// ModuleRestarting defines status of the module awaiting for restart after its routine ended.
+	ModuleRestarting ModuleStatus = "restarting"
+	// ModuleStopped defines status of the module, which routine ended and won't be restarted.
+	ModuleStopped ModuleStatus = "stopped"
+	// ModuleFailed defines status of the module, which routine failed and won't be restarted.
+	ModuleFailed ModuleStatus = "failed"
+)
+
+// ModuleHealth defines health state of the device module.
+type ModuleHealth struct {
+	// MID is a module identifier.
+	MID string `json:"mid"`
+	// Status is a current operational status of the module.
+	Status ModuleStatus `json:"status"`
+	// Policy is a restart policy applied to the module.
+	Policy RestartPolicy `json:"policy"`
+	// Restarts is a count of module routine restarts.
+	Restarts int `json:"restarts"`
+	// LastError is an error, which caused the latest module failure.
+	LastError string `json:"last_error,omitempty"`
+	// StartedAt is a time of the latest module routine start.
+	StartedAt time.Time `json:"started_at"`
+}
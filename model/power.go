@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/timoth-y/chainmetric-core/models"
+)
+
+// BatteryStatus extends models.DeviceBattery with fuel-gauge telemetry, which is available on the device side only.
+type BatteryStatus struct {
+	models.DeviceBattery
+	// Voltage is a battery cell voltage in millivolts.
+	Voltage float64 `json:"voltage"`
+	// Rate is a state of charge change rate in percents per hour, which is positive when charging.
+	// It is zero until enough samples are collected for estimation.
+	Rate float64 `json:"rate"`
+	// TimeToEmpty is an estimated time left until battery is discharged, zero when it is unknown or charging.
+	TimeToEmpty time.Duration `json:"time_to_empty"`
+	// TimeToFull is an estimated time left until battery is fully charged, zero when it is unknown or discharging.
+	TimeToFull time.Duration `json:"time_to_full"`
+	// Alert determines whether fuel-gauge raised low battery alert.
+	Alert bool `json:"alert"`
+	// PowerDraw is an average device power consumption since the previous status in mW,
+	// zero when power meter isn't available.
+	PowerDraw float64 `json:"power_draw"`
+	// EnergyPerReading is an average energy spent per handled readings request since the previous status in mJ,
+	// zero when power meter isn't available or no requests were handled.
+	EnergyPerReading float64 `json:"energy_per_reading"`
+}
+
+// PowerReading defines single measurement of the power rail.
+type PowerReading struct {
+	// Voltage is a rail voltage in V.
+	Voltage float64
+	// Current is a rail current in mA.
+	Current float64
+	// Power is a consumed power in mW.
+	Power float64
+}
+
+// PowerProfile defines name of the power profile device operates under.
+type PowerProfile string
+
+const (
+	// PowerMains identifies profile for device powered from mains, which operates at full capacity.
+	PowerMains PowerProfile = "mains"
+	// PowerBattery identifies profile for device running on battery.
+	PowerBattery PowerProfile = "battery"
+	// PowerLowBattery identifies profile for device running on battery with low level.
+	PowerLowBattery PowerProfile = "low_battery"
+	// PowerCritical identifies profile for device running on battery, which is about to be discharged.
+	PowerCritical PowerProfile = "critical"
+)
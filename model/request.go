@@ -13,6 +13,7 @@ type SensorsReadingRequest struct {
 	AssetID string
 	Period  time.Duration
 	Metrics models.Metrics
+	Limits  models.RequirementsMap
 	cancel  context.CancelFunc
 }
 
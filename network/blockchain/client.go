@@ -111,6 +111,11 @@ func Init() (err error) {
 	return nil
 }
 
+// IsConnected determines whether the client is connected to blockchain network.
+func IsConnected() bool {
+	return client.network != nil
+}
+
 // Close closes connection to blockchain network and clears allocated resources.
 func Close() {
 	client.gateway.Close()
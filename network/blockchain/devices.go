@@ -12,6 +12,7 @@ import (
 	"github.com/timoth-y/chainmetric-core/models"
 	"github.com/timoth-y/chainmetric-core/models/requests"
 
+	"github.com/timoth-y/chainmetric-iot/model"
 	"github.com/timoth-y/chainmetric-iot/shared"
 )
 
@@ -160,3 +161,13 @@ func (dc *DevicesContract) SubmitCommandResults(id string, req requests.DeviceCo
 
 	return nil
 }
+
+// SubmitCommandOutput submits command execution results along with its progress and structured output
+// to log them in the blockchain ledger. Status and error are logged the same way as with SubmitCommandResults.
+func (dc *DevicesContract) SubmitCommandOutput(id string, results model.DeviceCommandResults) error {
+	if _, err := dc.contract.SubmitTransaction("SubmitCommandResults", id, string(results.Encode())); err != nil {
+		return errors.Wrapf(err, "failed to submit command execution results for id '%s'", id)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package localnet
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -18,6 +19,10 @@ type Client struct {
 var (
 	client *Client
 
+	// suspended determines whether local network communication is suspended, e.g. to save power.
+	suspended     bool
+	suspendedLock = &sync.Mutex{}
+
 	// Channels exposes available channel for local network communication.
 	Channels = struct {
 		Geo *GeoLocationChannel
@@ -58,6 +63,10 @@ func Pair(ctx context.Context) error {
 		return errors.New("advertising unavailable since bluetooth does not enabled")
 	}
 
+	if !Available() {
+		return errors.New("advertising unavailable since bluetooth is suspended")
+	}
+
 	shared.Logger.Debug("Bluetooth pairing started")
 
 	if err := client.dev.Advertise(ctx); err != nil {
@@ -67,6 +76,23 @@ func Pair(ctx context.Context) error {
 	return nil
 }
 
+// SetAvailable suspends or resumes local network communication, e.g. to save power.
+// While suspended, pairing requests are refused.
+func SetAvailable(available bool) {
+	suspendedLock.Lock()
+	defer suspendedLock.Unlock()
+
+	suspended = !available
+}
+
+// Available determines whether local network communication is enabled and isn't suspended.
+func Available() bool {
+	suspendedLock.Lock()
+	defer suspendedLock.Unlock()
+
+	return viper.GetBool("bluetooth.enabled") && !suspended
+}
+
 // SetDeviceName sets new `name` for identifying device on local network.
 func SetDeviceName(name string) {
 	client.dev.ApplyOptions(periphery.WithDeviceName(name))
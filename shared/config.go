@@ -15,6 +15,7 @@ func initConfig() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
 	viper.SetDefault("device.id_file_path", "../device.id")
+	viper.SetDefault("device.pause_file_path", "../device.paused")
 	viper.SetDefault("device.register_timeout_duration", "1m")
 	viper.SetDefault("device.i2c_scan_timeout", "100ms")
 	viper.SetDefault("device.hotswap_detect_interval", "3s")
@@ -25,6 +26,18 @@ func initConfig() {
 
 	viper.SetDefault("engine.sensor_sleep_standby_timeout", "1m")
 
+	viper.SetDefault("supervisor.restart_policy", "on-failure")
+	viper.SetDefault("supervisor.backoff", "1s")
+	viper.SetDefault("supervisor.max_backoff", "1m")
+	viper.SetDefault("supervisor.max_restarts", 0)
+
+	viper.SetDefault("remote.command_timeout", "1m")
+	viper.SetDefault("remote.reboot_command", "sudo reboot")
+	viper.SetDefault("remote.config_path", "../config.remote.yaml")
+	viper.SetDefault("remote.config_backup_path", "../config.remote.lkg.yaml")
+	viper.SetDefault("remote.config_confirm_timeout", "5m")
+	viper.SetDefault("remote.config_boot_attempts", 3)
+
 	viper.SetDefault("blockchain.connection_config", "connection.yaml")
 	viper.SetDefault("blockchain.identity.certificate", "../identity.pem")
 	viper.SetDefault("blockchain.identity.private_key", "../identity.key")
@@ -35,14 +48,84 @@ func initConfig() {
 	viper.SetDefault("bluetooth.advertise_duration", "1m")
 
 	viper.SetDefault("sensors.analog.samples_per_read", 100)
+	viper.SetDefault("sensors.calibration_duration", "30s")
+	viper.SetDefault("sensors.bmxx80.temperature_oversampling", 2)
+	viper.SetDefault("sensors.bmxx80.pressure_oversampling", 16)
+	viper.SetDefault("sensors.bmxx80.humidity_oversampling", 1)
+	viper.SetDefault("sensors.bmxx80.filter", 4)
+	viper.SetDefault("sensors.bmxx80.heater_temperature", 320)
+	viper.SetDefault("sensors.bmxx80.heater_duration", "150ms")
+	viper.SetDefault("sensors.ndir.auto_self_calibration", true)
+	viper.SetDefault("sensors.ndir.measurement_interval", "2s")
+	viper.SetDefault("sensors.ndir.recalibration_duration", "5m")
+	viper.SetDefault("sensors.spectrum.adc_piezo.enabled", false)
+	viper.SetDefault("sensors.spectrum.adc_microphone.enabled", false)
+	viper.SetDefault("sensors.spectrum.adxl345.enabled", false)
+
+	viper.SetDefault("motion.sample_interval", "20ms")
+	viper.SetDefault("motion.baseline_samples", 50)
+	viper.SetDefault("motion.tilt_threshold", 30)
+	viper.SetDefault("motion.shock_threshold", 3)
+	viper.SetDefault("motion.shock_min_duration", "20ms")
+	viper.SetDefault("motion.free_fall_threshold", 0.3)
+	viper.SetDefault("motion.free_fall_min_duration", "100ms")
+
+	viper.SetDefault("power.alert_threshold", 10)
+	viper.SetDefault("power.alert_pin", 0)
+	viper.SetDefault("power.estimation_window", "30m")
+	viper.SetDefault("power.low_battery_level", 30)
+	viper.SetDefault("power.critical_level", 10)
+	viper.SetDefault("power.shutdown.level", 3)
+	viper.SetDefault("power.shutdown.voltage", 3300)
+	viper.SetDefault("power.shutdown.confirmations", 2)
+	viper.SetDefault("power.shutdown.command", "")
+	viper.SetDefault("power.meter.enabled", false)
+	viper.SetDefault("power.meter.address", 0x44)
+	viper.SetDefault("power.meter.bus", 1)
+	viper.SetDefault("power.meter.sample_interval", "1s")
+	viper.SetDefault("power.profiles.mains.bluetooth", true)
+	viper.SetDefault("power.profiles.mains.sampling_factor", 1)
+	viper.SetDefault("power.profiles.battery.hotswap_interval", "10s")
+	viper.SetDefault("power.profiles.battery.gui_update_interval", "2m")
+	viper.SetDefault("power.profiles.battery.sensor_standby_timeout", "30s")
+	viper.SetDefault("power.profiles.battery.bluetooth", true)
+	viper.SetDefault("power.profiles.battery.sampling_factor", 1)
+	viper.SetDefault("power.profiles.low_battery.hotswap_interval", "30s")
+	viper.SetDefault("power.profiles.low_battery.gui_update_interval", "5m")
+	viper.SetDefault("power.profiles.low_battery.sensor_standby_timeout", "15s")
+	viper.SetDefault("power.profiles.low_battery.bluetooth", false)
+	viper.SetDefault("power.profiles.low_battery.sampling_factor", 2)
+	viper.SetDefault("power.profiles.critical.hotswap_interval", "1m")
+	viper.SetDefault("power.profiles.critical.gui_update_interval", "10m")
+	viper.SetDefault("power.profiles.critical.sensor_standby_timeout", "5s")
+	viper.SetDefault("power.profiles.critical.bluetooth", false)
+	viper.SetDefault("power.profiles.critical.sampling_factor", 4)
 
 	viper.SetDefault("display.enabled", true)
+	viper.SetDefault("display.driver", "eink")
+	viper.SetDefault("display.brightness", 100)
+	viper.SetDefault("display.full_refresh_interval", 10)
+	viper.SetDefault("display.rotation", 0)
+	viper.SetDefault("display.frame_rate", 2)
 	viper.SetDefault("display.width", 240)
 	viper.SetDefault("display.height", 240)
 	viper.SetDefault("display.bus", "SPI0.0")
 	viper.SetDefault("display.dc_pin", 25)
 	viper.SetDefault("display.backlight_pin", 18)
 	viper.SetDefault("display.reset_pin", 15)
+	viper.SetDefault("display.i2c_bus", 1)
+	viper.SetDefault("display.address", 0x3C)
+	viper.SetDefault("display.virtual.output_dir", "frames")
+	viper.SetDefault("display.virtual.history_size", 50)
+
+	viper.SetDefault("gui.idle_timeout", "30s")
+	viper.SetDefault("gui.asset_cycle_interval", "5s")
+	viper.SetDefault("gui.buttons.enabled", false)
+	viper.SetDefault("gui.buttons.next_pin", 20)
+	viper.SetDefault("gui.buttons.prev_pin", 21)
+	viper.SetDefault("gui.buttons.select_pin", 16)
+	viper.SetDefault("gui.buttons.debounce", "30ms")
+	viper.SetDefault("gui.buttons.long_press", "1s")
 
 	viper.SetDefault("mocks.debug_env", false)
 	viper.SetDefault("mocks.sensor_duration", "250ms")
@@ -58,6 +141,8 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err != nil {
 		Logger.Error(errors.Wrap(err, "failed to read viper config"))
 	}
+
+	initConfigOverlay()
 }
 
 // UnmarshalFromConfig retrieves config block by given `key` and decodes it into given structure `v`.
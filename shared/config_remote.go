@@ -0,0 +1,266 @@
+package shared
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// configOverlay defines configuration changed remotely, which is persisted apart from the configuration file
+// and is applied over it on startup, so that operator-managed file stays untouched.
+type configOverlay struct {
+	Version  int                    `yaml:"version"`
+	Pending  bool                   `yaml:"pending,omitempty"`
+	Boots    int                    `yaml:"boots,omitempty"`
+	Settings map[string]interface{} `yaml:"settings"`
+}
+
+var (
+	overlay     configOverlay
+	overlayLock = &sync.Mutex{}
+)
+
+// ConfigVersion returns version of the configuration patch applied last, or zero if none was.
+func ConfigVersion() int {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+
+	return overlay.Version
+}
+
+// ConfigPatchPending determines whether the configuration patch applied last awaits confirmation.
+func ConfigPatchPending() bool {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+
+	return overlay.Pending
+}
+
+// ApplyConfigPatch validates YAML (or JSON) configuration `patch` with given `version` against the schema,
+// persists it keeping the previous remote configuration as the last-known-good one,
+// and applies changes of the keys, which don't require restart, right away.
+// Returns keys applied live and ones which would be applied after restart.
+//
+// Patch changing any key, which requires restart, stays pending until confirmed with ConfirmConfigPatch,
+// otherwise it is rolled back after `remote.config_boot_attempts` unsuccessful startups.
+func ApplyConfigPatch(version int, patch []byte) (live, restart []string, err error) {
+	var (
+		raw      interface{}
+		settings = make(map[string]interface{})
+	)
+
+	if err = yaml.Unmarshal(patch, &raw); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse config patch")
+	}
+
+	if _, ok := raw.(map[interface{}]interface{}); !ok {
+		return nil, nil, errors.New("config patch must be a mapping of keys to values")
+	}
+
+	flattenConfig("", raw, settings)
+
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+
+	if overlay.Pending {
+		return nil, nil, errors.Errorf("config patch of version %d is still pending confirmation", overlay.Version)
+	}
+
+	if version <= overlay.Version {
+		return nil, nil, errors.Errorf("config patch version must be greater than current %d", overlay.Version)
+	}
+
+	var next = configOverlay{
+		Version:  version,
+		Settings: make(map[string]interface{}, len(overlay.Settings)+len(settings)),
+	}
+
+	for key, value := range overlay.Settings {
+		next.Settings[key] = value
+	}
+
+	for key, value := range settings {
+		if next.Settings[key], err = validateConfigValue(key, value); err != nil {
+			return nil, nil, err
+		}
+
+		if configSchema[key].Live {
+			live = append(live, key)
+		} else {
+			restart = append(restart, key)
+		}
+	}
+
+	sort.Strings(live)
+	sort.Strings(restart)
+
+	next.Pending = len(restart) != 0
+
+	if err = copyConfigFile(viper.GetString("remote.config_path"), viper.GetString("remote.config_backup_path")); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to backup last-known-good config")
+	}
+
+	if err = writeConfigOverlay(next); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to persist config patch")
+	}
+
+	overlay = next
+
+	for _, key := range live {
+		viper.Set(key, next.Settings[key])
+	}
+
+	Logger.Infof("Config patch of version %d is applied: %d keys live, %d keys after restart",
+		version, len(live), len(restart))
+
+	return live, restart, nil
+}
+
+// ConfirmConfigPatch confirms the configuration patch pending since before restart,
+// so that it won't be rolled back. It is intended to be called once the device is logged on network.
+func ConfirmConfigPatch() {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+
+	if !overlay.Pending || overlay.Boots == 0 {
+		return
+	} // Patch applied during this run is confirmed only after restart.
+
+	var confirmed = overlay
+	confirmed.Pending = false
+	confirmed.Boots = 0
+
+	if err := writeConfigOverlay(confirmed); err != nil {
+		Logger.Error(errors.Wrap(err, "failed to confirm config patch"))
+		return
+	}
+
+	overlay = confirmed
+
+	Logger.Infof("Config patch of version %d is confirmed", overlay.Version)
+}
+
+// RollbackConfigPatch restores the last-known-good remote configuration, which takes effect after restart.
+func RollbackConfigPatch() error {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+
+	return rollbackConfigOverlay()
+}
+
+// initConfigOverlay reads remote configuration and applies it over the one read from configuration file.
+// Pending configuration patch is rolled back once it exceeds `remote.config_boot_attempts` startups.
+func initConfigOverlay() {
+	overlayLock.Lock()
+	defer overlayLock.Unlock()
+
+	if err := readConfigOverlay(); err != nil {
+		Logger.Error(errors.Wrap(err, "failed to read remote config"))
+		return
+	}
+
+	if overlay.Pending {
+		if overlay.Boots++; overlay.Boots > viper.GetInt("remote.config_boot_attempts") {
+			Logger.Warningf("Config patch of version %d wasn't confirmed in %d startups",
+				overlay.Version, overlay.Boots-1)
+
+			if err := rollbackConfigOverlay(); err != nil {
+				Logger.Error(errors.Wrap(err, "failed to rollback config patch"))
+			}
+		} else if err := writeConfigOverlay(overlay); err != nil {
+			Logger.Error(errors.Wrap(err, "failed to persist config patch startup attempt"))
+		}
+	}
+
+	// Values are set over the ones from configuration file and environment, regardless of their original type:
+	for key, value := range overlay.Settings {
+		viper.Set(key, value)
+	}
+}
+
+func rollbackConfigOverlay() error {
+	if err := copyConfigFile(viper.GetString("remote.config_backup_path"), viper.GetString("remote.config_path")); err != nil {
+		return err
+	}
+
+	if err := readConfigOverlay(); err != nil {
+		return err
+	}
+
+	Logger.Warningf("Config is rolled back to the last-known-good version %d", overlay.Version)
+
+	return nil
+}
+
+func readConfigOverlay() error {
+	overlay = configOverlay{}
+
+	payload, err := ioutil.ReadFile(viper.GetString("remote.config_path"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return yaml.Unmarshal(payload, &overlay)
+}
+
+func writeConfigOverlay(o configOverlay) error {
+	var path = viper.GetString("remote.config_path")
+
+	payload, err := yaml.Marshal(o)
+	if err != nil {
+		return err
+	}
+
+	// File is replaced at once, so that it won't be left corrupted if device loses power while writing:
+	if err = ioutil.WriteFile(path+".tmp", payload, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// copyConfigFile copies file from `src` to `dst` path, or removes `dst` one if there is no `src` file.
+func copyConfigFile(src, dst string) error {
+	payload, err := ioutil.ReadFile(src)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		if err = os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	return ioutil.WriteFile(dst, payload, 0600)
+}
+
+// flattenConfig flattens nested configuration `v` into `flat` map by dot-separated lowercase keys.
+func flattenConfig(prefix string, v interface{}, flat map[string]interface{}) {
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		flat[strings.ToLower(prefix)] = v
+		return
+	}
+
+	for key, value := range m {
+		if len(prefix) != 0 {
+			flattenConfig(fmt.Sprintf("%s.%v", prefix, key), value, flat)
+		} else {
+			flattenConfig(fmt.Sprint(key), value, flat)
+		}
+	}
+}
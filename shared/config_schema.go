@@ -0,0 +1,157 @@
+package shared
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/timoth-y/chainmetric-iot/model"
+)
+
+// ConfigValueKind defines kind of the configuration value.
+type ConfigValueKind string
+
+const (
+	// ConfigDuration defines kind of the duration configuration value, e.g. "30s".
+	ConfigDuration ConfigValueKind = "duration"
+	// ConfigInt defines kind of the integer configuration value.
+	ConfigInt ConfigValueKind = "int"
+	// ConfigFloat defines kind of the floating point configuration value.
+	ConfigFloat ConfigValueKind = "float"
+	// ConfigBool defines kind of the boolean configuration value.
+	ConfigBool ConfigValueKind = "bool"
+	// ConfigString defines kind of the string configuration value.
+	ConfigString ConfigValueKind = "string"
+)
+
+// ConfigKeySchema defines schema of the configuration key, which can be changed remotely.
+type ConfigKeySchema struct {
+	Kind ConfigValueKind
+	// Min and Max bound numeric values, and durations in seconds. Range is checked only when Max is greater than Min.
+	Min, Max float64
+	// Enum restricts value to the listed ones, compared by their string representation.
+	Enum []string
+	// Live determines whether change is applied without restart, which is so for the keys read each time those are used.
+	Live bool
+}
+
+// configSchema defines configuration keys, which can be changed remotely.
+// Keys pointing to file paths or shell commands are deliberately left out.
+// Keys copied on startup or into the power profile settings, e.g. `engine.sensor_sleep_standby_timeout`,
+// `gui.*` or `display.brightness`, aren't live, since those would be overridden by the copy.
+var configSchema = map[string]ConfigKeySchema{
+	"device.ping_timer_interval":          {Kind: ConfigDuration, Min: 1, Max: 3600, Live: true},
+	"device.assets_locate_distance":       {Kind: ConfigFloat, Min: 1, Max: 10000, Live: true},
+	"device.register_timeout_duration":    {Kind: ConfigDuration, Min: 10, Max: 3600, Live: true},
+	"device.i2c_scan_timeout":             {Kind: ConfigDuration, Min: 0.01, Max: 10, Live: true},
+	"device.hotswap_detect_interval":      {Kind: ConfigDuration, Min: 1, Max: 3600},
+	"device.battery_check_interval":       {Kind: ConfigDuration, Min: 1, Max: 3600},
+	"device.gui_update_interval":          {Kind: ConfigDuration, Min: 1, Max: 3600},
+	"engine.sensor_sleep_standby_timeout": {Kind: ConfigDuration, Min: 1, Max: 3600},
+	"remote.command_timeout":              {Kind: ConfigDuration, Min: 10, Max: 3600, Live: true},
+	"sensors.calibration_duration":        {Kind: ConfigDuration, Min: 1, Max: 600, Live: true},
+	"sensors.analog.samples_per_read":     {Kind: ConfigInt, Min: 1, Max: 10000},
+	"bluetooth.enabled":                   {Kind: ConfigBool},
+	"modules.motion_monitor.enabled":      {Kind: ConfigBool},
+	"motion.tilt_threshold":               {Kind: ConfigFloat, Min: 1, Max: 180},
+	"motion.shock_threshold":              {Kind: ConfigFloat, Min: 0.5, Max: 16},
+	"motion.free_fall_threshold":          {Kind: ConfigFloat, Min: 0.05, Max: 1},
+	"power.low_battery_level":             {Kind: ConfigInt, Min: 0, Max: 100},
+	"power.critical_level":                {Kind: ConfigInt, Min: 0, Max: 100},
+	"power.shutdown.level":                {Kind: ConfigInt, Min: 0, Max: 100},
+	"display.enabled":                     {Kind: ConfigBool},
+	"display.brightness":                  {Kind: ConfigInt, Min: 0, Max: 100},
+	"display.rotation":                    {Kind: ConfigInt, Enum: []string{"0", "90", "180", "270"}},
+	"display.frame_rate":                  {Kind: ConfigInt, Min: 0, Max: 60, Live: true},
+	"display.full_refresh_interval":       {Kind: ConfigInt, Min: 0, Max: 1000, Live: true},
+	"gui.idle_timeout":                    {Kind: ConfigDuration, Min: 1, Max: 3600},
+	"gui.asset_cycle_interval":            {Kind: ConfigDuration, Min: 1, Max: 3600},
+	"supervisor.restart_policy": {Kind: ConfigString, Enum: []string{
+		string(model.RestartNever), string(model.RestartOnFailure), string(model.RestartAlways),
+	}},
+	"supervisor.max_restarts": {Kind: ConfigInt, Min: 0, Max: 1000},
+}
+
+// validateConfigValue validates `value` of the configuration `key` against its schema
+// and returns it normalized to the form it would have in the configuration file.
+func validateConfigValue(key string, value interface{}) (interface{}, error) {
+	var (
+		schema, ok = configSchema[key]
+		number     float64
+	)
+
+	if !ok {
+		return nil, errors.Errorf("key '%s' can't be changed remotely", key)
+	}
+
+	switch schema.Kind {
+	case ConfigDuration:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("key '%s' must be duration, got %T", key, value)
+		}
+
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "key '%s' must be duration", key)
+		}
+
+		number = d.Seconds()
+	case ConfigInt:
+		if number, ok = toNumber(value); !ok || number != math.Trunc(number) {
+			return nil, errors.Errorf("key '%s' must be integer, got %v", key, value)
+		}
+
+		value = int(number)
+	case ConfigFloat:
+		if number, ok = toNumber(value); !ok {
+			return nil, errors.Errorf("key '%s' must be number, got %T", key, value)
+		}
+
+		value = number
+	case ConfigBool:
+		if _, ok = value.(bool); !ok {
+			return nil, errors.Errorf("key '%s' must be bool, got %T", key, value)
+		}
+	case ConfigString:
+		if _, ok = value.(string); !ok {
+			return nil, errors.Errorf("key '%s' must be string, got %T", key, value)
+		}
+	}
+
+	if schema.Max > schema.Min && (number < schema.Min || number > schema.Max) {
+		return nil, errors.Errorf("key '%s' must be in range from %v to %v, got %v", key, schema.Min, schema.Max, value)
+	}
+
+	if len(schema.Enum) != 0 {
+		var allowed bool
+
+		for _, v := range schema.Enum {
+			if v == fmt.Sprint(value) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return nil, errors.Errorf("key '%s' must be one of %v, got %v", key, schema.Enum, value)
+		}
+	}
+
+	return value, nil
+}
+
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}